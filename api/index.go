@@ -25,16 +25,28 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	db := config.NewDatabase(viper, log)
 	redis := config.NewRedisClient(viper, log)
 	jwt := config.NewJWT(viper)
+	trash := config.NewTrash(viper)
+	// A function invocation does not live long enough to run the purge loop
+	trash.PurgeInterval = 0
+	store := config.NewStorage(viper, log)
+	attachment := config.NewAttachment(viper)
+	mail := config.NewMailer(viper, log)
+	verification := config.NewVerification(viper)
 	validate := config.NewValidator()
 	app, log := config.NewEcho()
 
 	err := config.Bootstrap(&config.BootstrapConfig{
-		DB:       db,
-		Cache:    redis,
-		App:      app,
-		Log:      log,
-		Validate: validate,
-		JWT:      jwt,
+		DB:           db,
+		Cache:        redis,
+		App:          app,
+		Log:          log,
+		Validate:     validate,
+		JWT:          jwt,
+		Trash:        trash,
+		Storage:      store,
+		Attachment:   attachment,
+		Mailer:       mail,
+		Verification: verification,
 	})
 	if err != nil {
 		log.Fatalf("Failed to bootstrap application: %v", err)
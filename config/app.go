@@ -5,36 +5,79 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/savioruz/mikti-task/internal/delivery/graph/handler"
 	"github.com/savioruz/mikti-task/internal/delivery/graph/resolvers"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/attachment"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/comment"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/jwks"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/project"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/share"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/tag"
 	"github.com/savioruz/mikti-task/internal/delivery/http/handler/todo"
 	"github.com/savioruz/mikti-task/internal/delivery/http/handler/user"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/workspace"
 	"github.com/savioruz/mikti-task/internal/delivery/http/middleware"
 	"github.com/savioruz/mikti-task/internal/delivery/http/route"
+	"github.com/savioruz/mikti-task/internal/domain/model"
 	"github.com/savioruz/mikti-task/internal/platform/cache"
 	"github.com/savioruz/mikti-task/internal/platform/jwt"
+	"github.com/savioruz/mikti-task/internal/platform/mailer"
+	"github.com/savioruz/mikti-task/internal/platform/storage"
+	activityRepo "github.com/savioruz/mikti-task/internal/repositories/activity"
+	attachmentRepo "github.com/savioruz/mikti-task/internal/repositories/attachment"
+	commentRepo "github.com/savioruz/mikti-task/internal/repositories/comment"
+	projectRepo "github.com/savioruz/mikti-task/internal/repositories/project"
+	sessionRepo "github.com/savioruz/mikti-task/internal/repositories/session"
+	shareRepo "github.com/savioruz/mikti-task/internal/repositories/share"
+	tagRepo "github.com/savioruz/mikti-task/internal/repositories/tag"
 	todoRepo "github.com/savioruz/mikti-task/internal/repositories/todo"
 	userRepo "github.com/savioruz/mikti-task/internal/repositories/user"
+	workspaceRepo "github.com/savioruz/mikti-task/internal/repositories/workspace"
+	attachmentUsecase "github.com/savioruz/mikti-task/internal/usecases/attachment"
+	commentUsecase "github.com/savioruz/mikti-task/internal/usecases/comment"
+	projectUsecase "github.com/savioruz/mikti-task/internal/usecases/project"
+	shareUsecase "github.com/savioruz/mikti-task/internal/usecases/share"
+	tagUsecase "github.com/savioruz/mikti-task/internal/usecases/tag"
 	todoUsecase "github.com/savioruz/mikti-task/internal/usecases/todo"
 	userUsecase "github.com/savioruz/mikti-task/internal/usecases/user"
+	workspaceUsecase "github.com/savioruz/mikti-task/internal/usecases/workspace"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type BootstrapConfig struct {
-	DB       *gorm.DB
-	Cache    *cache.ImplCache
-	App      *echo.Echo
-	Log      *logrus.Logger
-	Validate *validator.Validate
-	JWT      *jwt.JWTConfig
+	DB           *gorm.DB
+	Cache        *cache.ImplCache
+	App          *echo.Echo
+	Log          *logrus.Logger
+	Validate     *validator.Validate
+	JWT          *jwt.JWTConfig
+	Trash        *TrashConfig
+	Storage      storage.Storage
+	Attachment   *AttachmentConfig
+	Mailer       mailer.Mailer
+	Verification *userUsecase.VerificationConfig
 }
 
 func Bootstrap(config *BootstrapConfig) error {
 	// Initialize repositories
 	todoRepository := todoRepo.NewTodoRepository(config.DB, config.Log)
 	userRepository := userRepo.NewUserRepository(config.DB, config.Log)
+	tagRepository := tagRepo.NewTagRepository(config.DB, config.Log)
+	projectRepository := projectRepo.NewProjectRepository(config.DB, config.Log)
+	attachmentRepository := attachmentRepo.NewAttachmentRepository(config.DB, config.Log)
+	commentRepository := commentRepo.NewCommentRepository(config.DB, config.Log)
+	activityRepository := activityRepo.NewActivityRepository(config.DB, config.Log)
+	shareRepository := shareRepo.NewShareRepository(config.DB, config.Log)
+	workspaceRepository := workspaceRepo.NewWorkspaceRepository(config.DB, config.Log)
+	memberRepository := workspaceRepo.NewMemberRepository(config.DB, config.Log)
+	inviteRepository := workspaceRepo.NewInviteRepository(config.DB, config.Log)
+	sessionRepository := sessionRepo.NewSessionRepository(config.DB, config.Log)
 
 	// Initialize JWT service
-	jwtService := jwt.NewJWTService(config.JWT)
+	jwtService, err := jwt.NewJWTService(config.JWT)
+	if err != nil {
+		return err
+	}
+	denylist := jwt.NewCacheDenylist(config.Cache)
 
 	// Initialize usecases
 	todoUC := todoUsecase.NewTodoUsecaseImpl(
@@ -43,6 +86,76 @@ func Bootstrap(config *BootstrapConfig) error {
 		config.Log,
 		config.Validate,
 		todoRepository,
+		tagRepository,
+		projectRepository,
+		shareRepository,
+		attachmentRepository,
+		config.Storage,
+		activityRepository,
+		config.Trash.Retention,
+	)
+
+	tagUC := tagUsecase.NewTagUsecaseImpl(
+		config.DB,
+		config.Cache,
+		config.Log,
+		config.Validate,
+		tagRepository,
+	)
+
+	projectUC := projectUsecase.NewProjectUsecaseImpl(
+		config.DB,
+		config.Cache,
+		config.Log,
+		config.Validate,
+		projectRepository,
+		todoRepository,
+		shareRepository,
+	)
+
+	attachmentUC := attachmentUsecase.NewAttachmentUsecaseImpl(
+		config.DB,
+		config.Log,
+		config.Validate,
+		attachmentRepository,
+		todoRepository,
+		shareRepository,
+		config.Storage,
+		config.Attachment.MaxSize,
+		config.Attachment.AllowedTypes,
+	)
+
+	commentUC := commentUsecase.NewCommentUsecaseImpl(
+		config.DB,
+		config.Log,
+		config.Validate,
+		commentRepository,
+		todoRepository,
+		shareRepository,
+	)
+
+	shareUC := shareUsecase.NewShareUsecaseImpl(
+		config.DB,
+		config.Cache,
+		config.Log,
+		config.Validate,
+		shareRepository,
+		todoRepository,
+		projectRepository,
+		userRepository,
+		memberRepository,
+	)
+
+	workspaceUC := workspaceUsecase.NewWorkspaceUsecaseImpl(
+		config.DB,
+		config.Cache,
+		config.Log,
+		config.Validate,
+		workspaceRepository,
+		memberRepository,
+		inviteRepository,
+		shareRepository,
+		userRepository,
 	)
 
 	userUC := userUsecase.NewUserUsecaseImpl(
@@ -50,30 +163,60 @@ func Bootstrap(config *BootstrapConfig) error {
 		config.Log,
 		config.Validate,
 		userRepository,
+		workspaceRepository,
+		memberRepository,
+		sessionRepository,
 		jwtService,
+		denylist,
+		config.JWT.RefreshExpiry,
+		config.Mailer,
+		config.Verification,
 	)
 
 	// Initialize handlers
 	todoHandler := todo.NewTodoHandlerImpl(config.Log, todoUC)
 	userHandler := user.NewUserHandlerImpl(config.Log, userUC)
+	tagHandler := tag.NewTagHandlerImpl(config.Log, tagUC)
+	projectHandler := project.NewProjectHandlerImpl(config.Log, projectUC)
+	attachmentHandler := attachment.NewAttachmentHandlerImpl(config.Log, attachmentUC, config.Attachment.MaxSize)
+	commentHandler := comment.NewCommentHandlerImpl(config.Log, commentUC)
+	todoShareHandler := share.NewShareHandlerImpl(config.Log, shareUC, model.ShareResourceTodo)
+	projectShareHandler := share.NewShareHandlerImpl(config.Log, shareUC, model.ShareResourceProject)
+	workspaceHandler := workspace.NewWorkspaceHandlerImpl(config.Log, workspaceUC)
+	jwksHandler := jwks.NewJWKSHandlerImpl(config.Log, jwtService)
 
 	// Initialize GraphQL
-	resolver := resolvers.NewResolver(todoUC)
+	resolver := resolvers.NewResolver(todoUC, projectUC, commentUC, userUC)
 	graphQLHandler := handler.NewGraphQLHandler(resolver)
 
 	// Initialize middleware
-	authMiddleware := middleware.AuthMiddleware(jwtService)
+	authMiddleware := middleware.AuthMiddleware(jwtService, denylist)
+	workspaceMiddleware := middleware.WorkspaceMiddleware(workspaceUC)
 
 	// Setup routes
 	routeConfig := &route.Config{
-		App:            config.App,
-		GraphQLHandler: graphQLHandler,
-		TodoHandler:    todoHandler,
-		UserHandler:    userHandler,
-		AuthMiddleware: authMiddleware,
+		App:                 config.App,
+		GraphQLHandler:      graphQLHandler,
+		TodoHandler:         todoHandler,
+		UserHandler:         userHandler,
+		TagHandler:          tagHandler,
+		ProjectHandler:      projectHandler,
+		AttachmentHandler:   attachmentHandler,
+		CommentHandler:      commentHandler,
+		TodoShareHandler:    todoShareHandler,
+		ProjectShareHandler: projectShareHandler,
+		WorkspaceHandler:    workspaceHandler,
+		JWKSHandler:         jwksHandler,
+		AuthMiddleware:      authMiddleware,
+		WorkspaceMiddleware: workspaceMiddleware,
 	}
 	routeConfig.Setup()
 
+	// Start purging expired trash
+	if config.Trash.Retention > 0 && config.Trash.PurgeInterval > 0 {
+		go purgeTrash(todoUC, config.Log, config.Trash.PurgeInterval)
+	}
+
 	config.Log.Info("Application is ready")
 	return nil
 }
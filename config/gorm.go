@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"gorm.io/driver/postgres"
@@ -44,6 +46,11 @@ func NewDatabase(viper *viper.Viper, log *logrus.Logger) *gorm.DB {
 	connection.SetMaxOpenConns(100)
 	connection.SetConnMaxLifetime(time.Second * time.Duration(300))
 
+	// Keep todos and projects inside the workspace of the request, raw SQL adds repositories.TenantCondition itself
+	if err := repositories.RegisterTenantScope(db, &entity.Todo{}, &entity.Project{}); err != nil {
+		log.Fatalf("failed to register tenant scope: %v", err)
+	}
+
 	//if err := db.AutoMigrate(&entities.Todo{}, &entities.User{}); err != nil {
 	//	log.Fatalf("failed to migrate database: %v", err)
 	//}
@@ -3,12 +3,26 @@ package config
 import (
 	"github.com/savioruz/mikti-task/internal/platform/jwt"
 	"github.com/spf13/viper"
+	"strings"
 )
 
 func NewJWT(viper *viper.Viper) *jwt.JWTConfig {
-	return &jwt.JWTConfig{
-		Secret:        viper.GetString("JWT_SECRET"),
-		AccessExpiry:  viper.GetDuration("JWT_ACCESS_EXPIRY"),
-		RefreshExpiry: viper.GetDuration("JWT_REFRESH_EXPIRY"),
+	config := &jwt.JWTConfig{
+		Secret:         viper.GetString("JWT_SECRET"),
+		AccessExpiry:   viper.GetDuration("JWT_ACCESS_EXPIRY"),
+		RefreshExpiry:  viper.GetDuration("JWT_REFRESH_EXPIRY"),
+		Issuer:         viper.GetString("JWT_ISSUER"),
+		Audience:       viper.GetString("JWT_AUDIENCE"),
+		PrivateKeyFile: viper.GetString("JWT_PRIVATE_KEY_FILE"),
 	}
+
+	if files := viper.GetString("JWT_PUBLIC_KEY_FILES"); files != "" {
+		for _, f := range strings.Split(files, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				config.PublicKeyFiles = append(config.PublicKeyFiles, f)
+			}
+		}
+	}
+
+	return config
 }
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"github.com/savioruz/mikti-task/internal/platform/mailer"
+	"github.com/savioruz/mikti-task/internal/usecases/user"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"time"
+)
+
+const (
+	defaultMailFilePath       = "./mail"
+	defaultSMTPPort           = 587
+	defaultVerificationExpiry = 24 * time.Hour
+)
+
+// NewMailer creates the mailer selected by MAIL_DRIVER, messages are only logged unless set to smtp or file
+func NewMailer(viper *viper.Viper, log *logrus.Logger) mailer.Mailer {
+	from := viper.GetString("MAIL_FROM")
+
+	switch driver := viper.GetString("MAIL_DRIVER"); driver {
+	case "", "log":
+		return mailer.NewLogMailer(log)
+	case "file":
+		dir := viper.GetString("MAIL_FILE_PATH")
+		if dir == "" {
+			dir = defaultMailFilePath
+		}
+
+		m, err := mailer.NewFileMailer(dir, from)
+		if err != nil {
+			log.Fatalf("failed to create mail directory: %v", err)
+		}
+		return m
+	case "smtp":
+		port := viper.GetInt("SMTP_PORT")
+		if port <= 0 {
+			port = defaultSMTPPort
+		}
+
+		return mailer.NewSMTPMailer(&mailer.SMTPConfig{
+			Host:     viper.GetString("SMTP_HOST"),
+			Port:     port,
+			Username: viper.GetString("SMTP_USERNAME"),
+			Password: viper.GetString("SMTP_PASSWORD"),
+			From:     from,
+		})
+	default:
+		log.Fatalf("unknown mail driver: %s", driver)
+		return nil
+	}
+}
+
+func NewVerification(viper *viper.Viper) *user.VerificationConfig {
+	config := &user.VerificationConfig{
+		Required: viper.GetBool("EMAIL_VERIFICATION_REQUIRED"),
+		Expiry:   viper.GetDuration("EMAIL_VERIFICATION_EXPIRY"),
+		URL:      viper.GetString("EMAIL_VERIFICATION_URL"),
+	}
+	if config.Expiry <= 0 {
+		config.Expiry = defaultVerificationExpiry
+	}
+	if config.URL == "" {
+		config.URL = "http://localhost:" + viper.GetString("APP_PORT") + "/api/v1/users/verify"
+	}
+
+	return config
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"context"
+	"github.com/savioruz/mikti-task/internal/platform/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"strings"
+)
+
+const (
+	defaultAttachmentMaxSize = 10 << 20
+	defaultStorageLocalPath  = "./uploads"
+)
+
+var defaultAttachmentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+type AttachmentConfig struct {
+	// MaxSize is the largest file in bytes that can be attached to a todo
+	MaxSize int64
+	// AllowedTypes are the content types accepted, matched against the detected type
+	AllowedTypes []string
+}
+
+func NewAttachment(viper *viper.Viper) *AttachmentConfig {
+	config := &AttachmentConfig{
+		MaxSize:      viper.GetInt64("ATTACHMENT_MAX_SIZE"),
+		AllowedTypes: defaultAttachmentTypes,
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultAttachmentMaxSize
+	}
+
+	if types := viper.GetString("ATTACHMENT_ALLOWED_TYPES"); types != "" {
+		config.AllowedTypes = nil
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				config.AllowedTypes = append(config.AllowedTypes, t)
+			}
+		}
+	}
+
+	return config
+}
+
+// NewStorage creates the attachment storage selected by STORAGE_DRIVER, local disk unless set to s3
+func NewStorage(viper *viper.Viper, log *logrus.Logger) storage.Storage {
+	switch driver := viper.GetString("STORAGE_DRIVER"); driver {
+	case "", "local":
+		root := viper.GetString("STORAGE_LOCAL_PATH")
+		if root == "" {
+			root = defaultStorageLocalPath
+		}
+
+		s, err := storage.NewLocalStorage(root)
+		if err != nil {
+			log.Fatalf("failed to create storage directory: %v", err)
+		}
+		return s
+	case "s3":
+		region := viper.GetString("S3_REGION")
+		s, err := storage.NewS3Storage(&storage.S3Config{
+			Endpoint:  viper.GetString("S3_ENDPOINT"),
+			Region:    region,
+			Bucket:    viper.GetString("S3_BUCKET"),
+			AccessKey: viper.GetString("S3_ACCESS_KEY"),
+			SecretKey: viper.GetString("S3_SECRET_KEY"),
+			UseSSL:    viper.GetBool("S3_USE_SSL"),
+			PathStyle: viper.GetBool("S3_PATH_STYLE"),
+		})
+		if err != nil {
+			log.Fatalf("failed to create s3 client: %v", err)
+		}
+
+		if err := s.EnsureBucket(context.Background(), region); err != nil {
+			log.Fatalf("failed to connect s3: %v", err)
+		}
+		return s
+	default:
+		log.Fatalf("unknown storage driver: %s", driver)
+		return nil
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"context"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+	"github.com/savioruz/mikti-task/internal/usecases/todo"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"time"
+)
+
+type TrashConfig struct {
+	// Retention is how long deleted todos stay restorable, zero keeps them forever
+	Retention time.Duration
+	// PurgeInterval is how often expired todos are hard-deleted, zero disables the purge loop
+	PurgeInterval time.Duration
+}
+
+func NewTrash(viper *viper.Viper) *TrashConfig {
+	return &TrashConfig{
+		Retention:     viper.GetDuration("TRASH_RETENTION"),
+		PurgeInterval: viper.GetDuration("TRASH_PURGE_INTERVAL"),
+	}
+}
+
+// purgeTrash hard-deletes todos that outlived the retention window once per interval
+func purgeTrash(todoUC todo.TodoUsecase, log *logrus.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		// The purge covers the trash of every workspace
+		purged, err := todoUC.PurgeTrash(tenant.WithAllWorkspaces(context.Background()))
+		if err != nil {
+			log.Errorf("failed to purge trash: %v", err)
+			continue
+		}
+
+		if purged > 0 {
+			log.Infof("purged %d todos from trash", purged)
+		}
+	}
+}
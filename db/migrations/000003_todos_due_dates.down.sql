@@ -0,0 +1,7 @@
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_due_at;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS start_at,
+    DROP COLUMN IF EXISTS due_at;
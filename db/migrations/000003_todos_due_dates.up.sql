@@ -0,0 +1,10 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS due_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS start_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS idx_todos_due_at
+    ON todos USING btree
+    (due_at ASC NULLS LAST)
+    TABLESPACE pg_default;
@@ -0,0 +1,9 @@
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_priority;
+
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS todos_priority_check;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,12 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS priority smallint NOT NULL DEFAULT 0;
+
+ALTER TABLE todos
+    ADD CONSTRAINT todos_priority_check CHECK (priority BETWEEN 0 AND 4);
+
+CREATE INDEX IF NOT EXISTS idx_todos_priority
+    ON todos USING btree
+    (priority DESC, due_at ASC NULLS LAST)
+    TABLESPACE pg_default;
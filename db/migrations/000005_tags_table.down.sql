@@ -0,0 +1,13 @@
+-- Table: public.todo_tags
+
+DROP TABLE IF EXISTS todo_tags;
+
+DROP INDEX IF EXISTS idx_todo_tags_tag_id;
+
+-- Table: public.tags
+
+DROP TABLE IF EXISTS tags;
+
+DROP INDEX IF EXISTS idx_tags_user_name;
+
+DROP INDEX IF EXISTS idx_tags_deleted_at;
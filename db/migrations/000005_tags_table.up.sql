@@ -0,0 +1,41 @@
+-- Table: public.tags
+
+CREATE TABLE IF NOT EXISTS tags (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    name varchar(50) COLLATE pg_catalog."default" NOT NULL,
+    user_id varchar(36) NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT tags_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_tags_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_name
+    ON tags USING btree
+    (user_id, name)
+    TABLESPACE pg_default
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tags_deleted_at
+    ON tags USING btree
+    (deleted_at ASC NULLS LAST)
+    TABLESPACE pg_default;
+
+-- Table: public.todo_tags
+
+CREATE TABLE IF NOT EXISTS todo_tags (
+    todo_id varchar(36) NOT NULL,
+    tag_id varchar(36) NOT NULL,
+    CONSTRAINT todo_tags_pkey PRIMARY KEY (todo_id, tag_id),
+    CONSTRAINT fk_todo_tags_todo FOREIGN KEY (todo_id)
+        REFERENCES todos (id) ON DELETE CASCADE,
+    CONSTRAINT fk_todo_tags_tag FOREIGN KEY (tag_id)
+        REFERENCES tags (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_todo_tags_tag_id
+    ON todo_tags USING btree
+    (tag_id)
+    TABLESPACE pg_default;
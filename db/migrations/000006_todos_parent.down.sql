@@ -0,0 +1,9 @@
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_parent_id;
+
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS fk_todos_parent;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS parent_id;
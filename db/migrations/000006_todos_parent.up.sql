@@ -0,0 +1,13 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS parent_id varchar(36);
+
+ALTER TABLE todos
+    ADD CONSTRAINT fk_todos_parent FOREIGN KEY (parent_id)
+        REFERENCES todos (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_todos_parent_id
+    ON todos USING btree
+    (parent_id)
+    TABLESPACE pg_default;
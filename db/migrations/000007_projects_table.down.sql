@@ -0,0 +1,17 @@
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_project_id;
+
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS fk_todos_project;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS project_id;
+
+-- Table: public.projects
+
+DROP TABLE IF EXISTS projects;
+
+DROP INDEX IF EXISTS idx_projects_user_id;
+
+DROP INDEX IF EXISTS idx_projects_deleted_at;
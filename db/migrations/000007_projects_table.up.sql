@@ -0,0 +1,39 @@
+-- Table: public.projects
+
+CREATE TABLE IF NOT EXISTS projects (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    name varchar(100) COLLATE pg_catalog."default" NOT NULL,
+    color varchar(7) COLLATE pg_catalog."default",
+    archived boolean NOT NULL DEFAULT false,
+    user_id varchar(36) NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT projects_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_projects_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_projects_user_id
+    ON projects USING btree
+    (user_id)
+    TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at
+    ON projects USING btree
+    (deleted_at ASC NULLS LAST)
+    TABLESPACE pg_default;
+
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS project_id varchar(36);
+
+ALTER TABLE todos
+    ADD CONSTRAINT fk_todos_project FOREIGN KEY (project_id)
+        REFERENCES projects (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_todos_project_id
+    ON todos USING btree
+    (project_id)
+    TABLESPACE pg_default;
@@ -0,0 +1,9 @@
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_series_id;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS recurrence,
+    DROP COLUMN IF EXISTS recurrence_start,
+    DROP COLUMN IF EXISTS recurrence_tz,
+    DROP COLUMN IF EXISTS series_id;
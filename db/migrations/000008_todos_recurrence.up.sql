@@ -0,0 +1,12 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS recurrence varchar(255),
+    ADD COLUMN IF NOT EXISTS recurrence_start timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS recurrence_tz varchar(64),
+    ADD COLUMN IF NOT EXISTS series_id varchar(36);
+
+CREATE INDEX IF NOT EXISTS idx_todos_series_id
+    ON todos USING btree
+    (series_id)
+    TABLESPACE pg_default;
@@ -0,0 +1,6 @@
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_search_vector;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS search_vector;
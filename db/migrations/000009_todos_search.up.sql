@@ -0,0 +1,10 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+        GENERATED ALWAYS AS (to_tsvector('english', coalesce(title, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_todos_search_vector
+    ON todos USING gin
+    (search_vector)
+    TABLESPACE pg_default;
@@ -0,0 +1,18 @@
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_search_vector;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS description;
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+        GENERATED ALWAYS AS (to_tsvector('english', coalesce(title, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_todos_search_vector
+    ON todos USING gin
+    (search_vector)
+    TABLESPACE pg_default;
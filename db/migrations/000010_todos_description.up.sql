@@ -0,0 +1,22 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS description text;
+
+-- Generated columns cannot be altered, the search vector is rebuilt to cover the description with a lower weight
+DROP INDEX IF EXISTS idx_todos_search_vector;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE todos
+    ADD COLUMN search_vector tsvector
+        GENERATED ALWAYS AS (
+            setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+            setweight(to_tsvector('english', coalesce(description, '')), 'B')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_todos_search_vector
+    ON todos USING gin
+    (search_vector)
+    TABLESPACE pg_default;
@@ -0,0 +1,5 @@
+-- Table: public.attachments
+
+DROP INDEX IF EXISTS idx_attachments_todo_id;
+
+DROP TABLE IF EXISTS attachments;
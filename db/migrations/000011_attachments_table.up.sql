@@ -0,0 +1,24 @@
+-- Table: public.attachments
+
+CREATE TABLE IF NOT EXISTS attachments (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    todo_id varchar(36) NOT NULL,
+    filename varchar(255) COLLATE pg_catalog."default" NOT NULL,
+    content_type varchar(255) COLLATE pg_catalog."default" NOT NULL,
+    size bigint NOT NULL,
+    storage_key varchar(512) COLLATE pg_catalog."default" NOT NULL,
+    user_id varchar(36) NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT attachments_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_attachments_todo FOREIGN KEY (todo_id)
+        REFERENCES todos (id) ON DELETE CASCADE,
+    CONSTRAINT fk_attachments_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_attachments_todo_id
+    ON attachments USING btree
+    (todo_id)
+    TABLESPACE pg_default;
@@ -0,0 +1,5 @@
+-- Table: public.comments
+
+DROP INDEX IF EXISTS idx_comments_todo_id_created_at;
+
+DROP TABLE IF EXISTS comments;
@@ -0,0 +1,22 @@
+-- Table: public.comments
+
+CREATE TABLE IF NOT EXISTS comments (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    todo_id varchar(36) NOT NULL,
+    user_id varchar(36) NOT NULL,
+    body text COLLATE pg_catalog."default" NOT NULL,
+    edited boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT comments_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_comments_todo FOREIGN KEY (todo_id)
+        REFERENCES todos (id) ON DELETE CASCADE,
+    CONSTRAINT fk_comments_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_comments_todo_id_created_at
+    ON comments USING btree
+    (todo_id, created_at)
+    TABLESPACE pg_default;
@@ -0,0 +1,7 @@
+-- Table: public.shares
+
+DROP INDEX IF EXISTS idx_shares_user_id;
+
+DROP INDEX IF EXISTS idx_shares_resource_user;
+
+DROP TABLE IF EXISTS shares;
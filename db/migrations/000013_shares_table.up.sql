@@ -0,0 +1,31 @@
+-- Table: public.shares
+
+CREATE TABLE IF NOT EXISTS shares (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    resource_type varchar(20) COLLATE pg_catalog."default" NOT NULL,
+    resource_id varchar(36) NOT NULL,
+    user_id varchar(36) NOT NULL,
+    role varchar(20) COLLATE pg_catalog."default" NOT NULL,
+    granted_by varchar(36) NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT shares_pkey PRIMARY KEY (id),
+    CONSTRAINT shares_resource_type_check CHECK (resource_type IN ('todo', 'project')),
+    CONSTRAINT shares_role_check CHECK (role IN ('viewer', 'editor', 'owner')),
+    CONSTRAINT fk_shares_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_shares_granted_by FOREIGN KEY (granted_by)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+-- One grant per user and resource
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shares_resource_user
+    ON shares USING btree
+    (resource_type, resource_id, user_id)
+    TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS idx_shares_user_id
+    ON shares USING btree
+    (user_id, resource_type)
+    TABLESPACE pg_default;
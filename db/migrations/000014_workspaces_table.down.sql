@@ -0,0 +1,37 @@
+-- Table: public.projects
+
+DROP INDEX IF EXISTS idx_projects_workspace_id;
+
+ALTER TABLE projects
+    DROP CONSTRAINT IF EXISTS fk_projects_workspace;
+
+ALTER TABLE projects
+    DROP COLUMN IF EXISTS workspace_id;
+
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_workspace_id;
+
+ALTER TABLE todos
+    DROP CONSTRAINT IF EXISTS fk_todos_workspace;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS workspace_id;
+
+-- Table: public.workspace_invites
+
+DROP INDEX IF EXISTS idx_workspace_invites_token_hash;
+
+DROP TABLE IF EXISTS workspace_invites;
+
+-- Table: public.workspace_members
+
+DROP INDEX IF EXISTS idx_workspace_members_user_id;
+
+DROP INDEX IF EXISTS idx_workspace_members_workspace_user;
+
+DROP TABLE IF EXISTS workspace_members;
+
+-- Table: public.workspaces
+
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,114 @@
+-- Table: public.workspaces
+
+CREATE TABLE IF NOT EXISTS workspaces (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    name varchar(100) COLLATE pg_catalog."default" NOT NULL,
+    personal boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT workspaces_pkey PRIMARY KEY (id)
+    );
+
+-- Table: public.workspace_members
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    workspace_id varchar(36) NOT NULL,
+    user_id varchar(36) NOT NULL,
+    role varchar(20) COLLATE pg_catalog."default" NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT workspace_members_pkey PRIMARY KEY (id),
+    CONSTRAINT workspace_members_role_check CHECK (role IN ('owner', 'admin', 'member', 'guest')),
+    CONSTRAINT fk_workspace_members_workspace FOREIGN KEY (workspace_id)
+        REFERENCES workspaces (id) ON DELETE CASCADE,
+    CONSTRAINT fk_workspace_members_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workspace_members_workspace_user
+    ON workspace_members USING btree
+    (workspace_id, user_id)
+    TABLESPACE pg_default;
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id
+    ON workspace_members USING btree
+    (user_id)
+    TABLESPACE pg_default;
+
+-- Table: public.workspace_invites
+
+CREATE TABLE IF NOT EXISTS workspace_invites (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    workspace_id varchar(36) NOT NULL,
+    email varchar(100) COLLATE pg_catalog."default" NOT NULL,
+    role varchar(20) COLLATE pg_catalog."default" NOT NULL,
+    token_hash varchar(64) COLLATE pg_catalog."default" NOT NULL,
+    invited_by varchar(36) NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    accepted_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT workspace_invites_pkey PRIMARY KEY (id),
+    CONSTRAINT workspace_invites_role_check CHECK (role IN ('admin', 'member', 'guest')),
+    CONSTRAINT fk_workspace_invites_workspace FOREIGN KEY (workspace_id)
+        REFERENCES workspaces (id) ON DELETE CASCADE,
+    CONSTRAINT fk_workspace_invites_invited_by FOREIGN KEY (invited_by)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workspace_invites_token_hash
+    ON workspace_invites USING btree
+    (token_hash)
+    TABLESPACE pg_default;
+
+-- Every existing user gets a personal workspace with their own ID, which takes over their todos and projects
+
+INSERT INTO workspaces (id, name, personal, created_at, updated_at)
+SELECT id, 'Personal', true, now(), now() FROM users
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO workspace_members (id, workspace_id, user_id, role, created_at, updated_at)
+SELECT gen_random_uuid()::varchar, id, id, 'owner', now(), now() FROM users
+ON CONFLICT (workspace_id, user_id) DO NOTHING;
+
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS workspace_id varchar(36);
+
+UPDATE todos SET workspace_id = user_id WHERE workspace_id IS NULL;
+
+ALTER TABLE todos
+    ALTER COLUMN workspace_id SET NOT NULL;
+
+ALTER TABLE todos
+    ADD CONSTRAINT fk_todos_workspace FOREIGN KEY (workspace_id)
+        REFERENCES workspaces (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_todos_workspace_id
+    ON todos USING btree
+    (workspace_id)
+    TABLESPACE pg_default;
+
+-- Table: public.projects
+
+ALTER TABLE projects
+    ADD COLUMN IF NOT EXISTS workspace_id varchar(36);
+
+UPDATE projects SET workspace_id = user_id WHERE workspace_id IS NULL;
+
+ALTER TABLE projects
+    ALTER COLUMN workspace_id SET NOT NULL;
+
+ALTER TABLE projects
+    ADD CONSTRAINT fk_projects_workspace FOREIGN KEY (workspace_id)
+        REFERENCES workspaces (id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_projects_workspace_id
+    ON projects USING btree
+    (workspace_id)
+    TABLESPACE pg_default;
@@ -0,0 +1,5 @@
+-- Table: public.todo_activities
+
+DROP INDEX IF EXISTS idx_todo_activities_todo_id_created_at;
+
+DROP TABLE IF EXISTS todo_activities;
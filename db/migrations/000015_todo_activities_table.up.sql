@@ -0,0 +1,22 @@
+-- Table: public.todo_activities
+
+CREATE TABLE IF NOT EXISTS todo_activities (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    todo_id varchar(36) NOT NULL,
+    user_id varchar(36) NOT NULL,
+    action varchar(20) COLLATE pg_catalog."default" NOT NULL,
+    changes jsonb NOT NULL DEFAULT '[]',
+    snapshot jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    CONSTRAINT todo_activities_pkey PRIMARY KEY (id),
+    CONSTRAINT todo_activities_action_check CHECK (action IN ('created', 'updated', 'completed', 'deleted', 'restored', 'reverted')),
+    CONSTRAINT fk_todo_activities_todo FOREIGN KEY (todo_id)
+        REFERENCES todos (id) ON DELETE CASCADE,
+    CONSTRAINT fk_todo_activities_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_todo_activities_todo_id_created_at
+    ON todo_activities USING btree
+    (todo_id, created_at)
+    TABLESPACE pg_default;
@@ -0,0 +1,4 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS version;
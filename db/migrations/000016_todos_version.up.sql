@@ -0,0 +1,4 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,6 @@
+-- Table: public.todos
+
+DROP INDEX IF EXISTS idx_todos_position;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS position;
@@ -0,0 +1,18 @@
+-- Table: public.todos
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS position double precision NOT NULL DEFAULT 0;
+
+-- Existing todos keep their creation order within each list
+UPDATE todos
+SET position = ranked.row * 1024
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY workspace_id, parent_id, project_id ORDER BY created_at, id) AS row
+    FROM todos
+) AS ranked
+WHERE todos.id = ranked.id;
+
+CREATE INDEX IF NOT EXISTS idx_todos_position
+    ON todos USING btree
+    (workspace_id, parent_id, project_id, position)
+    TABLESPACE pg_default;
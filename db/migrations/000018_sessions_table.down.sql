@@ -0,0 +1,5 @@
+-- Table: public.sessions
+
+DROP INDEX IF EXISTS idx_sessions_user_id;
+
+DROP TABLE IF EXISTS sessions;
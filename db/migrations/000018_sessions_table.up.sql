@@ -0,0 +1,22 @@
+-- Table: public.sessions
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    user_id varchar(36) NOT NULL,
+    token_id varchar(36) COLLATE pg_catalog."default" NOT NULL,
+    user_agent varchar(255) COLLATE pg_catalog."default",
+    expires_at timestamp with time zone NOT NULL,
+    last_used_at timestamp with time zone NOT NULL,
+    revoked_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    deleted_at timestamp with time zone,
+    CONSTRAINT sessions_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_sessions_user FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id
+    ON sessions USING btree
+    (user_id)
+    TABLESPACE pg_default;
@@ -0,0 +1,4 @@
+-- Table: public.users
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verified_at;
@@ -0,0 +1,9 @@
+-- Table: public.users
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_verified_at timestamp with time zone;
+
+-- Accounts from before verification was introduced were active from the start and count as verified
+UPDATE users
+SET email_verified_at = created_at
+WHERE status AND email_verified_at IS NULL;
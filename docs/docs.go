@@ -23,14 +23,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/todo": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "List the public keys access and refresh tokens are signed with, the kid in a token header picks the key. Empty while tokens are signed with a shared secret.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_platform_jwt.JWKS"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List todo",
+                "description": "List projects of the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -38,32 +58,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "project"
                 ],
-                "summary": "List todo",
+                "summary": "List projects",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order",
-                        "name": "order",
+                        "type": "boolean",
+                        "description": "Archived",
+                        "name": "archived",
                         "in": "query"
                     }
                 ],
@@ -71,7 +73,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_ProjectResponse"
                         }
                     },
                     "400": {
@@ -94,7 +96,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new todo",
+                "description": "Create a new project",
                 "consumes": [
                     "application/json"
                 ],
@@ -102,17 +104,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "project"
                 ],
-                "summary": "Create a new todo",
+                "summary": "Create a new project",
                 "parameters": [
                     {
-                        "description": "Todo data",
-                        "name": "todo",
+                        "description": "Project data",
+                        "name": "project",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoCreateRequest"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ProjectCreateRequest"
                         }
                     }
                 ],
@@ -120,7 +122,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_ProjectResponse"
                         }
                     },
                     "400": {
@@ -138,14 +140,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/todo/search": {
+        "/projects/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Search todo",
+                "description": "Get project by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -153,47 +155,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "project"
                 ],
-                "summary": "Search todo",
+                "summary": "Get project by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Title",
-                        "name": "title",
-                        "in": "query",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Size",
-                        "name": "size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order",
-                        "name": "order",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_ProjectResponse"
                         }
                     },
                     "400": {
@@ -202,6 +180,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -209,16 +193,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/todo/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get todo by ID",
+                "description": "Update project",
                 "consumes": [
                     "application/json"
                 ],
@@ -226,23 +208,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "project"
                 ],
-                "summary": "Get todo by ID",
+                "summary": "Update project",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Todo ID",
+                        "description": "Project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Project data",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ProjectUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_ProjectResponse"
                         }
                     },
                     "400": {
@@ -251,6 +242,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -259,13 +256,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update todo",
+                "description": "Delete project, its todos move to the inbox unless mode is cascade",
                 "consumes": [
                     "application/json"
                 ],
@@ -273,33 +270,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "project"
                 ],
-                "summary": "Update todo",
+                "summary": "Delete project",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Todo ID",
+                        "description": "Project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Todo data",
-                        "name": "todo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoUpdateRequest"
-                        }
+                        "type": "string",
+                        "description": "What happens to the todos (inbox, cascade)",
+                        "name": "mode",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -307,6 +298,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -314,14 +311,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/projects/{id}/shares": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete todo",
+                "description": "List the users a todo or project is shared with",
                 "consumes": [
                     "application/json"
                 ],
@@ -329,13 +328,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "todo"
+                    "share"
                 ],
-                "summary": "Delete todo",
+                "summary": "List shares",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Todo ID",
+                        "description": "Todo or project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -345,7 +344,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_ShareResponse"
                         }
                     },
                     "400": {
@@ -354,6 +353,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -361,11 +372,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users": {
+            },
             "post": {
-                "description": "Register a new user",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Grant a user viewer, editor or owner access, sharing again with the same user changes the role",
                 "consumes": [
                     "application/json"
                 ],
@@ -373,17 +387,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "share"
                 ],
-                "summary": "Register a new user",
+                "summary": "Share todo or project",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Todo or project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Share data",
+                        "name": "share",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.RegisterRequest"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ShareCreateRequest"
                         }
                     }
                 ],
@@ -391,7 +412,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_UserResponse"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_ShareResponse"
                         }
                     },
                     "400": {
@@ -400,6 +421,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -409,9 +442,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/login": {
-            "post": {
-                "description": "Login a user",
+        "/projects/{id}/shares/{share_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke a share, owners may revoke any share and recipients their own",
                 "consumes": [
                     "application/json"
                 ],
@@ -419,26 +457,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "share"
                 ],
-                "summary": "Login a user",
+                "summary": "Revoke share",
                 "parameters": [
                     {
-                        "description": "User data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Todo or project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share ID",
+                        "name": "share_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_UserResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -446,6 +486,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -455,9 +507,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/refresh": {
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List tags of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "List tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TagResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Refresh token",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new tag",
                 "consumes": [
                     "application/json"
                 ],
@@ -465,25 +554,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "tag"
                 ],
-                "summary": "Refresh token",
+                "summary": "Create a new tag",
                 "parameters": [
                     {
-                        "description": "Refresh token data",
-                        "name": "token",
+                        "description": "Tag data",
+                        "name": "tag",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.RefreshTokenRequest"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TagCreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TokenResponse"
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TagResponse"
                         }
                     },
                     "400": {
@@ -492,6 +581,185 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get tag by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Get tag by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Update tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag data",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TagUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete tag and detach it from every todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tag"
+                ],
+                "summary": "Delete tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -500,225 +768,4246 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_savioruz_mikti-task_internal_domain_model.Error": {
+        },
+        "/todo": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "List todo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort, position is the order arranged by hand",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Priority (none, low, medium, high, urgent)",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tag names",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag match mode (any, all)",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID, or inbox for todos without a project",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Due filter (overdue, today)",
+                        "name": "due",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Due before (RFC3339)",
+                        "name": "due_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Due after (RFC3339)",
+                        "name": "due_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. done:false AND created_at\u003e2026-01-01 AND title~\\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to read the todos after, replaces page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to read the todos before, replaces page",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the matching todos, defaults to true with page and false with cursors",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Create a new todo",
+                "parameters": [
+                    {
+                        "description": "Todo data",
+                        "name": "todo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Run create, update, complete, delete and move operations in one transaction. Responds 207 when any item failed, atomic mode rolls every item back in that case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Bulk todo operations",
+                "parameters": [
+                    {
+                        "description": "Operations",
+                        "name": "operations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoBulkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoBulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoBulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Full-text search over todo, ranked by relevance with highlighted matches",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Search todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full-text query, supports \\",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated, use q",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort, defaults to rank when searching",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated tag names",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag match mode (any, all)",
+                        "name": "tag_mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Project ID, or inbox for todos without a project",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. done:false AND created_at\u003e2026-01-01 AND title~\\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to read the todos after, replaces page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page to read the todos before, replaces page",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count the matching todos, defaults to true with page and false with cursors",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List deleted todo that can still be restored, most recently deleted first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "List trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get todo by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Get todo by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update todo. Send the ETag of the todo in If-Match to only update it when nobody changed it in between.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Update todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the todo must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Todo data",
+                        "name": "todo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the updated todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Delete todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete subtasks too, otherwise they move up one level",
+                        "name": "cascade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete for good instead of moving to the trash",
+                        "name": "permanent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Patch a todo with a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) against model.TodoPatchDocument. Fields can be cleared and tags edited one by one. Send the ETag of the todo in If-Match to only patch it when nobody changed it in between.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Patch todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the todo must still have",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch or JSON patch",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the patched todo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/activity": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List who changed what on a todo and when, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Todo activity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoActivityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/activity/{activity_id}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Put a todo back to the state it had after an entry of its activity, a parent or project that can no longer take it is a conflict",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Revert todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Activity ID",
+                        "name": "activity_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the files attached to a todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "List attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Attach a file to a todo, the type is detected from the content and checked against the allowed types",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "Upload attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/attachments/{attachment_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Download the content of an attachment",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "Download attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an attachment and its file",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachment"
+                ],
+                "summary": "Delete attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/children": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List direct subtasks of a todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "List subtasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the comments of a todo, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "List comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Post a comment on a todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Create comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment data",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.CommentUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/comments/{comment_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Edit a comment, only its author may change it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Update comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment data",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.CommentUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a comment, allowed for its author and the owner of the todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comment"
+                ],
+                "summary": "Delete comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Place a todo right after the after todo, right before the before todo, or between the two. The anchors must be in the same list as the todo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Move todo within its list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Anchors",
+                        "name": "anchors",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoReorderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/recurrence": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove the recurrence rule, the current occurrence stays as a regular todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "End series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a todo from the trash together with its deleted subtasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Restore todo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/shares": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the users a todo or project is shared with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "List shares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo or project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_ShareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Grant a user viewer, editor or owner access, sharing again with the same user changes the role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Share todo or project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo or project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Share data",
+                        "name": "share",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ShareCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_ShareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/shares/{share_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke a share, owners may revoke any share and recipients their own",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "share"
+                ],
+                "summary": "Revoke share",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo or project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share ID",
+                        "name": "share_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/todo/{id}/skip": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move a repeating todo to its next occurrence without completing it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "todo"
+                ],
+                "summary": "Skip occurrence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Todo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Register a new user, a link to verify the email is sent to it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "Login a user, workspace_id picks the default workspace of the tokens. Users who did not verify their email get 403 when verification is required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Login a user",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke the session of the calling token, its refresh token and the calling access token stop working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Logout a user",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/refresh": {
+            "post": {
+                "description": "Trade a refresh token for a new pair, the old refresh token stops working. Presenting a refresh token that was already traded revokes its session. Users who did not verify their email get 403 when verification is required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Refresh token",
+                "parameters": [
+                    {
+                        "description": "Refresh token data",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the open sessions of the calling user, current marks the session of the calling token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "List sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_SessionResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke every session of the calling user, or of the user in the path which needs an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke sessions",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke a session of the calling user, its refresh token stops working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/verify": {
+            "get": {
+                "description": "Verify the email of a user with the token of the link sent to it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Verify email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/verify/resend": {
+            "post": {
+                "description": "Send another verification link to an email that is not verified yet, the answer is the same for unknown emails",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Resend verification email",
+                "parameters": [
+                    {
+                        "description": "User data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke every session of the calling user, or of the user in the path which needs an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the workspaces the calling user is a member of, with their role in each",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "List workspaces",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_WorkspaceResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a workspace with the calling user as its owner",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Create workspace",
+                "parameters": [
+                    {
+                        "description": "Workspace data",
+                        "name": "workspace",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/invites/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Join the workspace of an invite addressed to the calling user's email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Accept workspace invite",
+                "parameters": [
+                    {
+                        "description": "Invite token",
+                        "name": "invite",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceInviteAcceptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a workspace the calling user is a member of",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Get workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rename a workspace, only its owner and admins may do so",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Update workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workspace data",
+                        "name": "workspace",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/invites": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Invite an email address to a workspace, the token in the response is shown only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Invite to workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invite data",
+                        "name": "invite",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceInviteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceInviteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the members of a workspace and their roles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "List workspace members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_WorkspaceMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the role of a member, the owner changes anyone but themselves and admins change members and guests",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Update workspace member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member data",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceMemberUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceMemberResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a member together with the shares they hold in the workspace, members may remove themselves to leave",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspace"
+                ],
+                "summary": "Remove workspace member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_savioruz_mikti-task_internal_domain_model.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "todo_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "description": "AuthorID is always shown, a thread is read by everyone with access to the todo",
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "todo_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.CommentUpdateRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Error": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 8
+                },
+                "workspace_id": {
+                    "description": "WorkspaceID picks the default workspace of the tokens, the personal workspace is used without it",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.PageMetadata": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.ProjectCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.ProjectResponse": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.ProjectUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 8
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.AttachmentResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_CommentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.CommentResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_ProjectResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ProjectResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_SessionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.SessionResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_ShareResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ShareResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TagResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TagResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoActivityResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoActivityResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_WorkspaceMemberResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceMemberResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_WorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceResponse"
+                    }
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.AttachmentResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_CommentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.CommentResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_ProjectResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ProjectResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_ShareResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.ShareResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TagResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TagResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoBulkResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoBulkResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TokenResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TokenResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_UserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.UserResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceInviteResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceInviteResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceMemberResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceMemberResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_WorkspaceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.WorkspaceResponse"
+                },
+                "error": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "Current marks the session of the calling token",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.ShareCreateRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "viewer",
+                        "editor",
+                        "owner"
+                    ]
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.ShareResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "granted_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "resource_type": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TagCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TagResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TagUpdateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoActivityChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoActivityResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "Changes is empty for deletes and restores, which do not change any field",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoActivityChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoSnapshot"
+                },
+                "todo_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoBulkOperation": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "cascade": {
+                    "description": "Cascade applies complete and delete to subtasks as well",
+                    "type": "boolean"
+                },
+                "create": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoCreateRequest"
+                },
+                "id": {
+                    "description": "ID is the target todo, required by every operation except create",
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "complete",
+                        "delete",
+                        "move"
+                    ]
+                },
+                "parent_id": {
+                    "description": "ParentID or ProjectID is where a move puts the todo, project_id also accepts inbox",
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "update": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoUpdateRequest"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoBulkRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "atomic": {
+                    "description": "Atomic discards every operation when one of them fails, otherwise only the failed ones are skipped",
+                    "type": "boolean"
+                },
+                "operations": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoBulkOperation"
+                    }
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoBulkResponse": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoBulkResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoBulkResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoResponse"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoCreateRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description is Markdown",
+                    "type": "string",
+                    "maxLength": 20000
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ]
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "description": "Recurrence is an RRULE such as FREQ=WEEKLY;BYDAY=MO, the series starts at due_at",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA zone occurrences keep their wall clock time in, defaults to UTC",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 5
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoProgress": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoReorderRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "after": {
+                    "type": "string"
+                },
+                "before": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt is only set on todos in the trash",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Description is Markdown, DescriptionHTML is its sanitized rendering",
+                    "type": "string"
+                },
+                "description_highlight": {
+                    "description": "DescriptionHighlight is a snippet of the description with the words matching a search in \u003cmark\u003e tags",
+                    "type": "string"
+                },
+                "description_html": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "highlight": {
+                    "description": "Highlight wraps the words matching a search in \u003cmark\u003e tags",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position is the place of the todo in its list when sorted by hand",
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "progress": {
+                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoProgress"
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "description": "Recurrence and Timezone are only set on the open occurrence of a repeating todo",
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TagResponse"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version goes up with every change, it is also sent as the ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoSnapshot": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "message": {
+                "done": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "type": "string"
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.LoginRequest": {
+        "github_com_savioruz_mikti-task_internal_domain_model.TodoUpdateRequest": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "cascade": {
+                    "description": "Cascade applies a change of done to every subtask as well",
+                    "type": "boolean"
+                },
+                "description": {
+                    "description": "Description replaces the Markdown description, an empty one clears it",
                     "type": "string",
-                    "maxLength": 100
+                    "maxLength": 20000
                 },
-                "password": {
+                "done": {
+                    "type": "boolean"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "low",
+                        "medium",
+                        "high",
+                        "urgent"
+                    ]
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "description": "Recurrence replaces the rule of a repeating todo, the series restarts at due_at",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Tags replaces the todo's tags when set, an empty list clears them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string",
                     "maxLength": 255,
-                    "minLength": 8
+                    "minLength": 5
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.PageMetadata": {
+        "github_com_savioruz_mikti-task_internal_domain_model.TokenResponse": {
             "type": "object",
             "properties": {
-                "page": {
-                    "type": "integer"
+                "access_token": {
+                    "type": "string"
                 },
-                "size": {
-                    "type": "integer"
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.UserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "total_items": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
                 },
-                "total_pages": {
-                    "type": "integer"
+                "email_verified_at": {
+                    "description": "EmailVerifiedAt is missing until the user verified their email",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.RefreshTokenRequest": {
+        "github_com_savioruz_mikti-task_internal_domain_model.WorkspaceCreateRequest": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "name"
             ],
             "properties": {
-                "refresh_token": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "github_com_savioruz_mikti-task_internal_domain_model.WorkspaceInviteAcceptRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.RegisterRequest": {
+        "github_com_savioruz_mikti-task_internal_domain_model.WorkspaceInviteRequest": {
             "type": "object",
             "required": [
                 "email",
-                "password"
+                "role"
             ],
             "properties": {
                 "email": {
                     "type": "string",
                     "maxLength": 100
                 },
-                "password": {
+                "role": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 8
+                    "enum": [
+                        "admin",
+                        "member",
+                        "guest"
+                    ]
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.Response-array_github_com_savioruz_mikti-task_internal_domain_model_TodoResponse": {
+        "github_com_savioruz_mikti-task_internal_domain_model.WorkspaceInviteResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoResponse"
-                    }
+                "email": {
+                    "type": "string"
                 },
-                "error": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                "expires_at": {
+                    "type": "string"
                 },
-                "paging": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
-                }
-            }
-        },
-        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TodoResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TodoResponse"
+                "id": {
+                    "type": "string"
                 },
-                "error": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                "role": {
+                    "type": "string"
                 },
-                "paging": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                "token": {
+                    "description": "Token is only returned when the invite is created, it is what the invited user accepts",
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_TokenResponse": {
+        "github_com_savioruz_mikti-task_internal_domain_model.WorkspaceMemberResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.TokenResponse"
+                "created_at": {
+                    "type": "string"
                 },
-                "error": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                "email": {
+                    "type": "string"
                 },
-                "paging": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
-                }
-            }
-        },
-        "github_com_savioruz_mikti-task_internal_domain_model.Response-github_com_savioruz_mikti-task_internal_domain_model_UserResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.UserResponse"
+                "role": {
+                    "type": "string"
                 },
-                "error": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.Error"
+                "updated_at": {
+                    "type": "string"
                 },
-                "paging": {
-                    "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_domain_model.PageMetadata"
+                "user_id": {
+                    "type": "string"
+                },
+                "workspace_id": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.TodoCreateRequest": {
+        "github_com_savioruz_mikti-task_internal_domain_model.WorkspaceMemberUpdateRequest": {
             "type": "object",
             "required": [
-                "title"
+                "role"
             ],
             "properties": {
-                "title": {
+                "role": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 5
+                    "enum": [
+                        "admin",
+                        "member",
+                        "guest"
+                    ]
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.TodoResponse": {
+        "github_com_savioruz_mikti-task_internal_domain_model.WorkspaceResponse": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "done": {
-                    "type": "boolean"
-                },
                 "id": {
                     "type": "string"
                 },
-                "title": {
+                "name": {
                     "type": "string"
                 },
-                "updated_at": {
+                "personal": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "description": "Role is the calling user's role in the workspace",
                     "type": "string"
                 },
-                "user_id": {
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.TodoUpdateRequest": {
+        "github_com_savioruz_mikti-task_internal_domain_model.WorkspaceUpdateRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "done": {
-                    "type": "boolean"
-                },
-                "title": {
+                "name": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 5
+                    "maxLength": 100,
+                    "minLength": 1
                 }
             }
         },
-        "github_com_savioruz_mikti-task_internal_domain_model.TokenResponse": {
+        "github_com_savioruz_mikti-task_internal_platform_jwt.JWK": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "alg": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "crv": {
                     "type": "string"
-                }
-            }
-        },
-        "github_com_savioruz_mikti-task_internal_domain_model.UserResponse": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                },
+                "e": {
                     "type": "string"
                 },
-                "email": {
+                "kid": {
                     "type": "string"
                 },
-                "id": {
+                "kty": {
                     "type": "string"
                 },
-                "role": {
+                "n": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "boolean"
+                "use": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "x": {
                     "type": "string"
                 }
             }
+        },
+        "github_com_savioruz_mikti-task_internal_platform_jwt.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_savioruz_mikti-task_internal_platform_jwt.JWK"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -6,6 +6,12 @@ import (
 	"github.com/savioruz/mikti-task/internal/domain/model"
 )
 
+type CommentResponse struct {
+	Data   []*model.CommentResponse `json:"data,omitempty"`
+	Paging *PageMetadata            `json:"paging"`
+	Error  *Error                   `json:"error,omitempty"`
+}
+
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -15,15 +21,23 @@ type Mutation struct {
 }
 
 type PageMetadata struct {
-	Page       int `json:"page"`
-	Size       int `json:"size"`
-	TotalItems int `json:"totalItems"`
-	TotalPages int `json:"totalPages"`
+	Page       int     `json:"page"`
+	Size       int     `json:"size"`
+	TotalItems *int    `json:"totalItems,omitempty"`
+	TotalPages *int    `json:"totalPages,omitempty"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+	PrevCursor *string `json:"prevCursor,omitempty"`
 }
 
 type Query struct {
 }
 
+type TodoActivityResponse struct {
+	Data   []*model.TodoActivityResponse `json:"data,omitempty"`
+	Paging *PageMetadata                 `json:"paging"`
+	Error  *Error                        `json:"error,omitempty"`
+}
+
 type TodoResponse struct {
 	Data   []*model.TodoResponse `json:"data,omitempty"`
 	Paging *PageMetadata         `json:"paging"`
@@ -1,7 +1,13 @@
 package resolvers
 
 import (
+	"encoding/json"
+	graphmodel "github.com/savioruz/mikti-task/internal/delivery/graph/model"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/usecases/comment"
+	"github.com/savioruz/mikti-task/internal/usecases/project"
 	"github.com/savioruz/mikti-task/internal/usecases/todo"
+	"github.com/savioruz/mikti-task/internal/usecases/user"
 )
 
 // This file will not be regenerated automatically.
@@ -9,11 +15,37 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	TodoUsecase todo.TodoUsecase
+	TodoUsecase    todo.TodoUsecase
+	ProjectUsecase project.ProjectUsecase
+	CommentUsecase comment.CommentUsecase
+	UserUsecase    user.UserUsecase
 }
 
-func NewResolver(t todo.TodoUsecase) *Resolver {
+func NewResolver(t todo.TodoUsecase, p project.ProjectUsecase, c comment.CommentUsecase, u user.UserUsecase) *Resolver {
 	return &Resolver{
-		TodoUsecase: t,
+		TodoUsecase:    t,
+		ProjectUsecase: p,
+		CommentUsecase: c,
+		UserUsecase:    u,
 	}
 }
+
+func pageMetadata(paging *model.PageMetadata) *graphmodel.PageMetadata {
+	return &graphmodel.PageMetadata{
+		Page:       paging.Page,
+		Size:       paging.Size,
+		TotalItems: paging.TotalItems,
+		TotalPages: paging.TotalPages,
+		NextCursor: paging.NextCursor,
+		PrevCursor: paging.PrevCursor,
+	}
+}
+
+func rawJSON(value json.RawMessage) *string {
+	if len(value) == 0 || string(value) == "null" {
+		return nil
+	}
+
+	s := string(value)
+	return &s
+}
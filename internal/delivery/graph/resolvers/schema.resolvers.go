@@ -6,6 +6,7 @@ package resolvers
 
 import (
 	"context"
+	"time"
 
 	"github.com/savioruz/mikti-task/internal/delivery/graph"
 	graphmodel "github.com/savioruz/mikti-task/internal/delivery/graph/model"
@@ -13,12 +14,23 @@ import (
 )
 
 // CreateTodo is the resolver for the createTodo field.
-func (r *mutationResolver) CreateTodo(ctx context.Context, title string) (*model.TodoResponse, error) {
-	return r.TodoUsecase.Create(ctx, &model.TodoCreateRequest{Title: title})
+func (r *mutationResolver) CreateTodo(ctx context.Context, title string, input *model.TodoCreateRequest) (*model.TodoResponse, error) {
+	request := &model.TodoCreateRequest{}
+	if input != nil {
+		request = input
+	}
+	request.Title = title
+
+	return r.TodoUsecase.Create(ctx, request)
 }
 
 // UpdateTodo is the resolver for the updateTodo field.
-func (r *mutationResolver) UpdateTodo(ctx context.Context, id string, input model.TodoUpdateRequest) (*model.TodoResponse, error) {
+func (r *mutationResolver) UpdateTodo(ctx context.Context, id string, input model.TodoUpdateRequest, expectedVersion *int) (*model.TodoResponse, error) {
+	if expectedVersion != nil {
+		version := int64(*expectedVersion)
+		input.ExpectedVersion = &version
+	}
+
 	u, err := r.TodoUsecase.Update(ctx, &model.TodoUpdateIDRequest{ID: id}, &input)
 	if err != nil {
 		return nil, err
@@ -28,8 +40,16 @@ func (r *mutationResolver) UpdateTodo(ctx context.Context, id string, input mode
 }
 
 // DeleteTodo is the resolver for the deleteTodo field.
-func (r *mutationResolver) DeleteTodo(ctx context.Context, id string) (bool, error) {
-	d, err := r.TodoUsecase.Delete(ctx, &model.TodoDeleteRequest{ID: id})
+func (r *mutationResolver) DeleteTodo(ctx context.Context, id string, cascade *bool, permanent *bool) (bool, error) {
+	request := &model.TodoDeleteRequest{ID: id}
+	if cascade != nil {
+		request.Cascade = *cascade
+	}
+	if permanent != nil {
+		request.Permanent = *permanent
+	}
+
+	d, err := r.TodoUsecase.Delete(ctx, request)
 	if err != nil {
 		return false, err
 	}
@@ -37,88 +57,292 @@ func (r *mutationResolver) DeleteTodo(ctx context.Context, id string) (bool, err
 	return d, nil
 }
 
+// RestoreTodo is the resolver for the restoreTodo field.
+func (r *mutationResolver) RestoreTodo(ctx context.Context, id string) (*model.TodoResponse, error) {
+	return r.TodoUsecase.Restore(ctx, &model.TodoGetRequest{ID: id})
+}
+
+// SkipOccurrence is the resolver for the skipOccurrence field.
+func (r *mutationResolver) SkipOccurrence(ctx context.Context, id string) (*model.TodoResponse, error) {
+	return r.TodoUsecase.Skip(ctx, &model.TodoGetRequest{ID: id})
+}
+
+// EndSeries is the resolver for the endSeries field.
+func (r *mutationResolver) EndSeries(ctx context.Context, id string) (*model.TodoResponse, error) {
+	return r.TodoUsecase.EndSeries(ctx, &model.TodoGetRequest{ID: id})
+}
+
+// RevertTodo is the resolver for the revertTodo field.
+func (r *mutationResolver) RevertTodo(ctx context.Context, id string, activityID string) (*model.TodoResponse, error) {
+	return r.TodoUsecase.Revert(ctx, &model.TodoRevertRequest{TodoID: id, ActivityID: activityID})
+}
+
+// ReorderTodo is the resolver for the reorderTodo field.
+func (r *mutationResolver) ReorderTodo(ctx context.Context, id string, after *string, before *string) (*model.TodoResponse, error) {
+	return r.TodoUsecase.Reorder(ctx, &model.TodoReorderRequest{ID: id, After: after, Before: before})
+}
+
+// CreateProject is the resolver for the createProject field.
+func (r *mutationResolver) CreateProject(ctx context.Context, input model.ProjectCreateRequest) (*model.ProjectResponse, error) {
+	return r.ProjectUsecase.Create(ctx, &input)
+}
+
+// UpdateProject is the resolver for the updateProject field.
+func (r *mutationResolver) UpdateProject(ctx context.Context, id string, input model.ProjectUpdateRequest) (*model.ProjectResponse, error) {
+	return r.ProjectUsecase.Update(ctx, &model.ProjectUpdateIDRequest{ID: id}, &input)
+}
+
+// DeleteProject is the resolver for the deleteProject field.
+func (r *mutationResolver) DeleteProject(ctx context.Context, id string, mode *string) (bool, error) {
+	request := &model.ProjectDeleteRequest{ID: id}
+	if mode != nil {
+		request.Mode = *mode
+	}
+
+	return r.ProjectUsecase.Delete(ctx, request)
+}
+
+// AddComment is the resolver for the addComment field.
+func (r *mutationResolver) AddComment(ctx context.Context, todoID string, body string) (*model.CommentResponse, error) {
+	return r.CommentUsecase.Create(ctx, &model.CommentCreateRequest{TodoID: todoID, Body: body})
+}
+
+// UpdateComment is the resolver for the updateComment field.
+func (r *mutationResolver) UpdateComment(ctx context.Context, todoID string, id string, body string) (*model.CommentResponse, error) {
+	return r.CommentUsecase.Update(ctx, &model.CommentGetRequest{TodoID: todoID, ID: id}, &model.CommentUpdateRequest{Body: body})
+}
+
+// DeleteComment is the resolver for the deleteComment field.
+func (r *mutationResolver) DeleteComment(ctx context.Context, todoID string, id string) (bool, error) {
+	return r.CommentUsecase.Delete(ctx, &model.CommentGetRequest{TodoID: todoID, ID: id})
+}
+
+// Logout is the resolver for the logout field.
+func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
+	return r.UserUsecase.Logout(ctx)
+}
+
 // Todo is the resolver for the todo field.
 func (r *queryResolver) Todo(ctx context.Context, id string) (*model.TodoResponse, error) {
 	return r.TodoUsecase.Get(ctx, &model.TodoGetRequest{ID: id})
 }
 
 // SearchTodos is the resolver for the searchTodos field.
-func (r *queryResolver) SearchTodos(ctx context.Context, title *string, page *int, size *int, sort *string, order *string) (*graphmodel.TodoResponse, error) {
-	var paginated *model.Response[[]*model.TodoResponse]
-	var err error
+func (r *queryResolver) SearchTodos(ctx context.Context, q *string, title *string, page *int, size *int, sort *string, order *string, tags []string, tagMode *string, projectID *string, filter *string, after *string, before *string, withTotal *bool) (*graphmodel.TodoResponse, error) {
+	request := &model.TodoSearchRequest{
+		Page:      1,
+		Size:      10,
+		Sort:      sort,
+		Order:     order,
+		Tags:      tags,
+		TagMode:   tagMode,
+		ProjectID: projectID,
+		After:     after,
+		Before:    before,
+		Total:     withTotal,
+	}
+	if q != nil {
+		request.Q = *q
+	}
+	if filter != nil {
+		request.Filter = *filter
+	}
+	if title != nil {
+		request.Title = *title
+	}
 	if page != nil && size != nil {
-		paginated, err = r.TodoUsecase.Search(ctx, &model.TodoSearchRequest{
-			Title: *title,
-			Page:  *page,
-			Size:  *size,
-			Sort:  sort,
-			Order: order,
-		})
-	} else {
-		paginated, err = r.TodoUsecase.Search(ctx, &model.TodoSearchRequest{
-			Title: *title,
-			Page:  1,
-			Size:  10,
-			Sort:  sort,
-			Order: order,
-		})
+		request.Page = *page
+		request.Size = *size
 	}
 
+	paginated, err := r.TodoUsecase.Search(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
 	return &graphmodel.TodoResponse{
-		Data: *paginated.Data,
-		Paging: &graphmodel.PageMetadata{
-			Page:       paginated.Paging.Page,
-			Size:       paginated.Paging.Size,
-			TotalItems: paginated.Paging.TotalItems,
-			TotalPages: paginated.Paging.TotalPages,
-		},
+		Data:   *paginated.Data,
+		Paging: pageMetadata(paginated.Paging),
 	}, nil
 }
 
+// Trash is the resolver for the trash field.
+func (r *queryResolver) Trash(ctx context.Context, page *int, size *int) (*graphmodel.TodoResponse, error) {
+	request := &model.TodoTrashRequest{
+		Page: 1,
+		Size: 10,
+	}
+	if page != nil && size != nil {
+		request.Page = *page
+		request.Size = *size
+	}
+
+	paginated, err := r.TodoUsecase.GetTrash(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphmodel.TodoResponse{
+		Data:   *paginated.Data,
+		Paging: pageMetadata(paginated.Paging),
+	}, nil
+}
+
+// Project is the resolver for the project field.
+func (r *queryResolver) Project(ctx context.Context, id string) (*model.ProjectResponse, error) {
+	return r.ProjectUsecase.Get(ctx, &model.ProjectGetRequest{ID: id})
+}
+
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context, archived *bool) ([]*model.ProjectResponse, error) {
+	projects, err := r.ProjectUsecase.GetAll(ctx, &model.ProjectGetAllRequest{Archived: archived})
+	if err != nil {
+		return nil, err
+	}
+
+	return *projects.Data, nil
+}
+
 // Todos is the resolver for the todos field.
-func (r *queryResolver) Todos(ctx context.Context, page *int, size *int, sort *string, order *string) (*graphmodel.TodoResponse, error) {
-	var paginated *model.Response[[]*model.TodoResponse]
-	var err error
+func (r *queryResolver) Todos(ctx context.Context, page *int, size *int, sort *string, order *string, priority *string, tags []string, tagMode *string, projectID *string, due *string, dueBefore *time.Time, dueAfter *time.Time, filter *string, after *string, before *string, withTotal *bool) (*graphmodel.TodoResponse, error) {
+	request := &model.TodoGetAllRequest{
+		Page:      1,
+		Size:      10,
+		Sort:      sort,
+		Order:     order,
+		Priority:  priority,
+		Tags:      tags,
+		TagMode:   tagMode,
+		ProjectID: projectID,
+		Due:       due,
+		DueBefore: dueBefore,
+		DueAfter:  dueAfter,
+		After:     after,
+		Before:    before,
+		Total:     withTotal,
+	}
+	if filter != nil {
+		request.Filter = *filter
+	}
 	if page != nil && size != nil {
-		paginated, err = r.TodoUsecase.GetAll(ctx, &model.TodoGetAllRequest{
-			Page:  *page,
-			Size:  *size,
-			Sort:  sort,
-			Order: order,
-		})
-	} else {
-		paginated, err = r.TodoUsecase.GetAll(ctx, &model.TodoGetAllRequest{
-			Page:  1,
-			Size:  10,
-			Sort:  sort,
-			Order: order,
-		})
+		request.Page = *page
+		request.Size = *size
 	}
 
+	paginated, err := r.TodoUsecase.GetAll(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
 	return &graphmodel.TodoResponse{
-		Data: *paginated.Data,
-		Paging: &graphmodel.PageMetadata{
-			Page:       paginated.Paging.Page,
-			Size:       paginated.Paging.Size,
-			TotalItems: paginated.Paging.TotalItems,
-			TotalPages: paginated.Paging.TotalPages,
-		},
+		Data:   *paginated.Data,
+		Paging: pageMetadata(paginated.Paging),
 	}, nil
 }
 
+// Parent is the resolver for the parent field.
+func (r *todoResolver) Parent(ctx context.Context, obj *model.TodoResponse) (*model.TodoResponse, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+
+	return r.TodoUsecase.Get(ctx, &model.TodoGetRequest{ID: *obj.ParentID})
+}
+
+// Children is the resolver for the children field.
+func (r *todoResolver) Children(ctx context.Context, obj *model.TodoResponse) ([]*model.TodoResponse, error) {
+	children, err := r.TodoUsecase.GetChildren(ctx, &model.TodoGetRequest{ID: obj.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return *children.Data, nil
+}
+
+// Project is the resolver for the project field.
+func (r *todoResolver) Project(ctx context.Context, obj *model.TodoResponse) (*model.ProjectResponse, error) {
+	if obj.ProjectID == nil {
+		return nil, nil
+	}
+
+	return r.ProjectUsecase.Get(ctx, &model.ProjectGetRequest{ID: *obj.ProjectID})
+}
+
+// Comments is the resolver for the comments field.
+func (r *todoResolver) Comments(ctx context.Context, obj *model.TodoResponse, page *int, size *int) (*graphmodel.CommentResponse, error) {
+	request := &model.CommentGetAllRequest{
+		TodoID: obj.ID,
+		Page:   1,
+		Size:   20,
+	}
+	if page != nil {
+		request.Page = *page
+	}
+	if size != nil {
+		request.Size = *size
+	}
+
+	paginated, err := r.CommentUsecase.GetAll(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphmodel.CommentResponse{
+		Data:   *paginated.Data,
+		Paging: pageMetadata(paginated.Paging),
+	}, nil
+}
+
+// Activity is the resolver for the activity field.
+func (r *todoResolver) Activity(ctx context.Context, obj *model.TodoResponse, page *int, size *int) (*graphmodel.TodoActivityResponse, error) {
+	request := &model.TodoActivityGetAllRequest{
+		TodoID: obj.ID,
+		Page:   1,
+		Size:   20,
+	}
+	if page != nil {
+		request.Page = *page
+	}
+	if size != nil {
+		request.Size = *size
+	}
+
+	paginated, err := r.TodoUsecase.GetActivity(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &graphmodel.TodoActivityResponse{
+		Data:   *paginated.Data,
+		Paging: pageMetadata(paginated.Paging),
+	}, nil
+}
+
+// Before is the resolver for the before field.
+func (r *todoActivityChangeResolver) Before(ctx context.Context, obj *model.TodoActivityChange) (*string, error) {
+	return rawJSON(obj.Before), nil
+}
+
+// After is the resolver for the after field.
+func (r *todoActivityChangeResolver) After(ctx context.Context, obj *model.TodoActivityChange) (*string, error) {
+	return rawJSON(obj.After), nil
+}
+
 // Mutation returns graph.MutationResolver implementation.
 func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
 
 // Query returns graph.QueryResolver implementation.
 func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
 
+// Todo returns graph.TodoResolver implementation.
+func (r *Resolver) Todo() graph.TodoResolver { return &todoResolver{r} }
+
+// TodoActivityChange returns graph.TodoActivityChangeResolver implementation.
+func (r *Resolver) TodoActivityChange() graph.TodoActivityChangeResolver {
+	return &todoActivityChangeResolver{r}
+}
+
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type todoResolver struct{ *Resolver }
+type todoActivityChangeResolver struct{ *Resolver }
@@ -0,0 +1,12 @@
+package attachment
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type AttachmentHandler interface {
+	Upload(ctx echo.Context) error
+	GetAll(ctx echo.Context) error
+	Download(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+}
@@ -0,0 +1,224 @@
+package attachment
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/usecases/attachment"
+	"github.com/sirupsen/logrus"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// multipartOverhead leaves room for the form boundaries and headers around the file
+const multipartOverhead = 1 << 20
+
+type AttachmentHandlerImpl struct {
+	Log        *logrus.Logger
+	Attachment attachment.AttachmentUsecase
+	// MaxSize caps the request body, parsing the form stops with a 413 once an upload goes past it
+	MaxSize int64
+}
+
+func NewAttachmentHandlerImpl(log *logrus.Logger, a attachment.AttachmentUsecase, maxSize int64) *AttachmentHandlerImpl {
+	return &AttachmentHandlerImpl{
+		Log:        log,
+		Attachment: a,
+		MaxSize:    maxSize,
+	}
+}
+
+// Upload function is a handler to attach a file to a todo
+// @Summary Upload attachment
+// @Description Attach a file to a todo, the type is detected from the content and checked against the allowed types
+// @Tags attachment
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param file formData file true "File"
+// @Success 201 {object} model.Response[model.AttachmentResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 413 {object} model.Error
+// @Failure 415 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/attachments [post]
+func (h *AttachmentHandlerImpl) Upload(ctx echo.Context) error {
+	// The cap goes on before anything reads the body, binding would parse the whole form without it
+	ctx.Request().Body = http.MaxBytesReader(ctx.Response(), ctx.Request().Body, h.MaxSize+multipartOverhead)
+
+	request := &model.AttachmentUploadRequest{
+		TodoID: ctx.Param("id"),
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		h.Log.Errorf("failed to read file: %v", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return handler.HandleError(ctx, http.StatusRequestEntityTooLarge, handler.ErrTooLarge)
+		}
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		h.Log.Errorf("failed to open file: %v", err)
+		return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+	}
+	defer file.Close()
+
+	request.Filename = header.Filename
+	request.Size = header.Size
+	request.Body = file
+
+	response, err := h.Attachment.Upload(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to upload attachment: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Request Entity Too Large":
+			return handler.HandleError(ctx, http.StatusRequestEntityTooLarge, handler.ErrTooLarge)
+		case err.Error() == "Unsupported Media Type":
+			return handler.HandleError(ctx, http.StatusUnsupportedMediaType, handler.ErrUnsupportedType)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusCreated, model.NewResponse(response, nil))
+}
+
+// GetAll function is a handler to list the attachments of a todo
+// @Summary List attachments
+// @Description List the files attached to a todo
+// @Tags attachment
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Success 200 {object} model.Response[[]model.AttachmentResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/attachments [get]
+func (h *AttachmentHandlerImpl) GetAll(ctx echo.Context) error {
+	request := new(model.TodoGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Attachment.GetAll(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to list attachments: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Download function is a handler to download an attachment
+// @Summary Download attachment
+// @Description Download the content of an attachment
+// @Tags attachment
+// @Produce octet-stream
+// @Param id path string true "Todo ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/attachments/{attachment_id} [get]
+func (h *AttachmentHandlerImpl) Download(ctx echo.Context) error {
+	request := new(model.AttachmentGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	download, err := h.Attachment.Download(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to download attachment: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+	defer download.Body.Close()
+
+	// Always download instead of rendering, so an uploaded file cannot run in the API's origin
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": download.Attachment.Filename}))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(download.Attachment.Size, 10))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+	return ctx.Stream(http.StatusOK, download.Attachment.ContentType, download.Body)
+}
+
+// Delete function is a handler to delete an attachment
+// @Summary Delete attachment
+// @Description Delete an attachment and its file
+// @Tags attachment
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Success 204
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandlerImpl) Delete(ctx echo.Context) error {
+	request := new(model.AttachmentGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	_, err := h.Attachment.Delete(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to delete attachment: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,12 @@
+package comment
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type CommentHandler interface {
+	Create(ctx echo.Context) error
+	GetAll(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+}
@@ -0,0 +1,189 @@
+package comment
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/usecases/comment"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type CommentHandlerImpl struct {
+	Log     *logrus.Logger
+	Comment comment.CommentUsecase
+}
+
+func NewCommentHandlerImpl(log *logrus.Logger, c comment.CommentUsecase) *CommentHandlerImpl {
+	return &CommentHandlerImpl{
+		Log:     log,
+		Comment: c,
+	}
+}
+
+// Create function is a handler to comment on a todo
+// @Summary Create comment
+// @Description Post a comment on a todo
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param comment body model.CommentUpdateRequest true "Comment data"
+// @Success 201 {object} model.Response[model.CommentResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/comments [post]
+func (h *CommentHandlerImpl) Create(ctx echo.Context) error {
+	request := new(model.CommentCreateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Comment.Create(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to create comment: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusCreated, model.NewResponse(response, nil))
+}
+
+// GetAll function is a handler to list the comments of a todo
+// @Summary List comments
+// @Description List the comments of a todo, oldest first
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param page query int false "Page number"
+// @Param size query int false "Page size"
+// @Success 200 {object} model.Response[[]model.CommentResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/comments [get]
+func (h *CommentHandlerImpl) GetAll(ctx echo.Context) error {
+	request := new(model.CommentGetAllRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Comment.GetAll(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to get comments: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Update function is a handler to edit a comment
+// @Summary Update comment
+// @Description Edit a comment, only its author may change it
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param comment_id path string true "Comment ID"
+// @Param comment body model.CommentUpdateRequest true "Comment data"
+// @Success 200 {object} model.Response[model.CommentResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/comments/{comment_id} [put]
+func (h *CommentHandlerImpl) Update(ctx echo.Context) error {
+	id := &model.CommentGetRequest{
+		TodoID: ctx.Param("id"),
+		ID:     ctx.Param("comment_id"),
+	}
+
+	request := new(model.CommentUpdateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request body: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Comment.Update(ctx.Request().Context(), id, request)
+	if err != nil {
+		h.Log.Errorf("failed to update comment: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// Delete function is a handler to delete a comment
+// @Summary Delete comment
+// @Description Delete a comment, allowed for its author and the owner of the todo
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param comment_id path string true "Comment ID"
+// @Success 204
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/comments/{comment_id} [delete]
+func (h *CommentHandlerImpl) Delete(ctx echo.Context) error {
+	request := new(model.CommentGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	_, err := h.Comment.Delete(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to delete comment: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
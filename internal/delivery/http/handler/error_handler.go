@@ -14,6 +14,10 @@ var (
 	ErrorConflict       = errors.New("conflict")
 	ErrNotFound         = errors.New("not found")
 	ErrForbidden        = errors.New("forbidden")
+	ErrPrecondition     = errors.New("precondition failed")
+	ErrTooLarge         = errors.New("file too large")
+	ErrUnsupportedType  = errors.New("unsupported file type")
+	ErrUnsupportedMedia = errors.New("unsupported media type")
 )
 
 func HandleError(c echo.Context, status int, err error) error {
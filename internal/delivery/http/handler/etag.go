@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag is the strong entity tag of a resource at a version
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// IfMatch reads the version an If-Match header asks for. A missing header or * puts no condition on the version.
+// ok is false when the header can never match, a weak or malformed tag as well as a list of several tags.
+func IfMatch(header string) (*int64, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return nil, false
+	}
+
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return &version, true
+}
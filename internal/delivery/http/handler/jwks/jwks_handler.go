@@ -0,0 +1,9 @@
+package jwks
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type JWKSHandler interface {
+	Keys(ctx echo.Context) error
+}
@@ -0,0 +1,33 @@
+package jwks
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/savioruz/mikti-task/internal/platform/jwt"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type JWKSHandlerImpl struct {
+	Log *logrus.Logger
+	JWT jwt.JWTService
+}
+
+func NewJWKSHandlerImpl(log *logrus.Logger, jwtService jwt.JWTService) *JWKSHandlerImpl {
+	return &JWKSHandlerImpl{
+		Log: log,
+		JWT: jwtService,
+	}
+}
+
+// Keys function is a handler to publish the token verification keys
+// @Summary JSON Web Key Set
+// @Description List the public keys access and refresh tokens are signed with, the kid in a token header picks the key. Empty while tokens are signed with a shared secret.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwt.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandlerImpl) Keys(ctx echo.Context) error {
+	// Verifiers may cache the keys for a while, a rotated key stays listed until its tokens expired
+	ctx.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return ctx.JSON(http.StatusOK, h.JWT.JWKS())
+}
@@ -0,0 +1,210 @@
+package project
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/usecases/project"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type ProjectHandlerImpl struct {
+	Log     *logrus.Logger
+	Project project.ProjectUsecase
+}
+
+func NewProjectHandlerImpl(log *logrus.Logger, p project.ProjectUsecase) *ProjectHandlerImpl {
+	return &ProjectHandlerImpl{
+		Log:     log,
+		Project: p,
+	}
+}
+
+// Create function is a handler to create a new project
+// @Summary Create a new project
+// @Description Create a new project
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param project body model.ProjectCreateRequest true "Project data"
+// @Success 201 {object} model.Response[model.ProjectResponse]
+// @Failure 400 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /projects [post]
+func (h *ProjectHandlerImpl) Create(ctx echo.Context) error {
+	request := new(model.ProjectCreateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Project.Create(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to create project: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusCreated, model.NewResponse(response, nil))
+}
+
+// GetByID function is a handler to get project by ID
+// @Summary Get project by ID
+// @Description Get project by ID
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {object} model.Response[model.ProjectResponse]
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /projects/{id} [get]
+func (h *ProjectHandlerImpl) GetByID(ctx echo.Context) error {
+	request := new(model.ProjectGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Project.Get(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to get project: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// Update function is a handler to update project
+// @Summary Update project
+// @Description Update project
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param project body model.ProjectUpdateRequest true "Project data"
+// @Success 200 {object} model.Response[model.ProjectResponse]
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /projects/{id} [put]
+func (h *ProjectHandlerImpl) Update(ctx echo.Context) error {
+	id := &model.ProjectUpdateIDRequest{
+		ID: ctx.Param("id"),
+	}
+
+	request := new(model.ProjectUpdateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request body: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Project.Update(ctx.Request().Context(), id, request)
+	if err != nil {
+		h.Log.Errorf("failed to update project: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// Delete function is a handler to delete project
+// @Summary Delete project
+// @Description Delete project, its todos move to the inbox unless mode is cascade
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param mode query string false "What happens to the todos (inbox, cascade)"
+// @Success 204
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /projects/{id} [delete]
+func (h *ProjectHandlerImpl) Delete(ctx echo.Context) error {
+	request := new(model.ProjectDeleteRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	_, err := h.Project.Delete(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to delete project: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
+
+// GetAll function is a handler to list projects of the current user
+// @Summary List projects
+// @Description List projects of the current user
+// @Tags project
+// @Accept json
+// @Produce json
+// @Param archived query bool false "Archived"
+// @Success 200 {object} model.Response[[]model.ProjectResponse]
+// @Failure 400 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /projects [get]
+func (h *ProjectHandlerImpl) GetAll(ctx echo.Context) error {
+	request := new(model.ProjectGetAllRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Project.GetAll(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to list project: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Unauthorized":
+			return handler.HandleError(ctx, http.StatusUnauthorized, handler.ErrorUnauthorized)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,11 @@
+package share
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type ShareHandler interface {
+	Create(ctx echo.Context) error
+	GetAll(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+}
@@ -0,0 +1,150 @@
+package share
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/usecases/share"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// ShareHandlerImpl serves the shares of one kind of resource, a handler is mounted for todos and one for projects
+type ShareHandlerImpl struct {
+	Log      *logrus.Logger
+	Share    share.ShareUsecase
+	Resource string
+}
+
+func NewShareHandlerImpl(log *logrus.Logger, s share.ShareUsecase, resource string) *ShareHandlerImpl {
+	return &ShareHandlerImpl{
+		Log:      log,
+		Share:    s,
+		Resource: resource,
+	}
+}
+
+// Create function is a handler to share a todo or project
+// @Summary Share todo or project
+// @Description Grant a user viewer, editor or owner access, sharing again with the same user changes the role
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo or project ID"
+// @Param share body model.ShareCreateRequest true "Share data"
+// @Success 201 {object} model.Response[model.ShareResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/shares [post]
+// @Router /projects/{id}/shares [post]
+func (h *ShareHandlerImpl) Create(ctx echo.Context) error {
+	request := new(model.ShareCreateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+	request.ResourceType = h.Resource
+
+	response, err := h.Share.Create(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to create share: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusCreated, model.NewResponse(response, nil))
+}
+
+// GetAll function is a handler to list the shares of a todo or project
+// @Summary List shares
+// @Description List the users a todo or project is shared with
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo or project ID"
+// @Success 200 {object} model.Response[[]model.ShareResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/shares [get]
+// @Router /projects/{id}/shares [get]
+func (h *ShareHandlerImpl) GetAll(ctx echo.Context) error {
+	request := new(model.ShareGetAllRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+	request.ResourceType = h.Resource
+
+	response, err := h.Share.GetAll(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to get shares: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Delete function is a handler to revoke a share
+// @Summary Revoke share
+// @Description Revoke a share, owners may revoke any share and recipients their own
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo or project ID"
+// @Param share_id path string true "Share ID"
+// @Success 204
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/shares/{share_id} [delete]
+// @Router /projects/{id}/shares/{share_id} [delete]
+func (h *ShareHandlerImpl) Delete(ctx echo.Context) error {
+	request := new(model.ShareDeleteRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+	request.ResourceType = h.Resource
+
+	_, err := h.Share.Delete(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to delete share: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
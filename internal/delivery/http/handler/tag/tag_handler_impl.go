@@ -0,0 +1,205 @@
+package tag
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/usecases/tag"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type TagHandlerImpl struct {
+	Log *logrus.Logger
+	Tag tag.TagUsecase
+}
+
+func NewTagHandlerImpl(log *logrus.Logger, t tag.TagUsecase) *TagHandlerImpl {
+	return &TagHandlerImpl{
+		Log: log,
+		Tag: t,
+	}
+}
+
+// Create function is a handler to create a new tag
+// @Summary Create a new tag
+// @Description Create a new tag
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param tag body model.TagCreateRequest true "Tag data"
+// @Success 201 {object} model.Response[model.TagResponse]
+// @Failure 400 {object} model.Error
+// @Failure 409 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /tags [post]
+func (h *TagHandlerImpl) Create(ctx echo.Context) error {
+	request := new(model.TagCreateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Tag.Create(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to create tag: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusCreated, model.NewResponse(response, nil))
+}
+
+// GetByID function is a handler to get tag by ID
+// @Summary Get tag by ID
+// @Description Get tag by ID
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 200 {object} model.Response[model.TagResponse]
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /tags/{id} [get]
+func (h *TagHandlerImpl) GetByID(ctx echo.Context) error {
+	request := new(model.TagGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Tag.Get(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to get tag: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// Update function is a handler to rename tag
+// @Summary Update tag
+// @Description Update tag
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param tag body model.TagUpdateRequest true "Tag data"
+// @Success 200 {object} model.Response[model.TagResponse]
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 409 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /tags/{id} [put]
+func (h *TagHandlerImpl) Update(ctx echo.Context) error {
+	id := &model.TagUpdateIDRequest{
+		ID: ctx.Param("id"),
+	}
+
+	request := new(model.TagUpdateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request body: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Tag.Update(ctx.Request().Context(), id, request)
+	if err != nil {
+		h.Log.Errorf("failed to update tag: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// Delete function is a handler to delete tag
+// @Summary Delete tag
+// @Description Delete tag and detach it from every todo
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 204
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /tags/{id} [delete]
+func (h *TagHandlerImpl) Delete(ctx echo.Context) error {
+	request := new(model.TagDeleteRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	_, err := h.Tag.Delete(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to delete tag: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
+
+// GetAll function is a handler to list tags of the current user
+// @Summary List tags
+// @Description List tags of the current user
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.Response[[]model.TagResponse]
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /tags [get]
+func (h *TagHandlerImpl) GetAll(ctx echo.Context) error {
+	response, err := h.Tag.GetAll(ctx.Request().Context())
+	if err != nil {
+		h.Log.Errorf("failed to list tag: %v", err)
+		switch {
+		case err.Error() == "Unauthorized":
+			return handler.HandleError(ctx, http.StatusUnauthorized, handler.ErrorUnauthorized)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
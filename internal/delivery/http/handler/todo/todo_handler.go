@@ -7,8 +7,18 @@ import (
 type TodoHandler interface {
 	Create(ctx echo.Context) error
 	Update(ctx echo.Context) error
+	Patch(ctx echo.Context) error
+	Bulk(ctx echo.Context) error
 	GetByID(ctx echo.Context) error
+	GetChildren(ctx echo.Context) error
+	Skip(ctx echo.Context) error
+	Reorder(ctx echo.Context) error
+	GetTrash(ctx echo.Context) error
+	Restore(ctx echo.Context) error
+	EndSeries(ctx echo.Context) error
 	Search(ctx echo.Context) error
 	GetAll(ctx echo.Context) error
+	GetActivity(ctx echo.Context) error
+	Revert(ctx echo.Context) error
 	Delete(ctx echo.Context) error
 }
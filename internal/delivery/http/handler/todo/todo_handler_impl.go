@@ -1,11 +1,15 @@
 package todo
 
 import (
+	"errors"
 	"github.com/labstack/echo/v4"
 	"github.com/savioruz/mikti-task/internal/delivery/http/handler"
 	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/platform/filter"
 	"github.com/savioruz/mikti-task/internal/usecases/todo"
 	"github.com/sirupsen/logrus"
+	"io"
+	"mime"
 	"net/http"
 )
 
@@ -43,9 +47,14 @@ func (h *TodoHandlerImpl) Create(ctx echo.Context) error {
 	response, err := h.Todo.Create(ctx.Request().Context(), request)
 	if err != nil {
 		h.Log.Errorf("failed to create todo: %v", err)
-		if err.Error() == "Bad Request" {
+		switch {
+		case err.Error() == "Bad Request":
 			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
-		} else {
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
 			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
 		}
 	}
@@ -61,6 +70,7 @@ func (h *TodoHandlerImpl) Create(ctx echo.Context) error {
 // @Produce json
 // @Param id path string true "Todo ID"
 // @Success 200 {object} model.Response[model.TodoResponse]
+// @Header 200 {string} ETag "Version of the todo"
 // @Failure 400 {object} model.Error
 // @Failure 500 {object} model.Error
 // @security ApiKeyAuth
@@ -85,19 +95,24 @@ func (h *TodoHandlerImpl) GetByID(ctx echo.Context) error {
 		}
 	}
 
+	ctx.Response().Header().Set("ETag", handler.ETag(response.Version))
 	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
 }
 
 // Update function is a handler to update todo
 // @Summary Update todo
-// @Description Update todo
+// @Description Update todo. Send the ETag of the todo in If-Match to only update it when nobody changed it in between.
 // @Tags todo
 // @Accept json
 // @Produce json
 // @Param id path string true "Todo ID"
+// @Param If-Match header string false "ETag the todo must still have"
 // @Param todo body model.TodoUpdateRequest true "Todo data"
 // @Success 200 {object} model.Response[model.TodoResponse]
+// @Header 200 {string} ETag "Version of the updated todo"
 // @Failure 400 {object} model.Error
+// @Failure 409 {object} model.Error
+// @Failure 412 {object} model.Error
 // @Failure 500 {object} model.Error
 // @security ApiKeyAuth
 // @Router /todo/{id} [put]
@@ -112,19 +127,143 @@ func (h *TodoHandlerImpl) Update(ctx echo.Context) error {
 		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
 	}
 
+	version, ok := handler.IfMatch(ctx.Request().Header.Get("If-Match"))
+	if !ok {
+		return handler.HandleError(ctx, http.StatusPreconditionFailed, handler.ErrPrecondition)
+	}
+	request.ExpectedVersion = version
+
 	response, err := h.Todo.Update(ctx.Request().Context(), id, request)
 	if err != nil {
 		h.Log.Errorf("failed to update todo: %v", err)
-		if err.Error() == "Bad Request" {
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		case err.Error() == "Precondition Failed":
+			return handler.HandleError(ctx, http.StatusPreconditionFailed, handler.ErrPrecondition)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	ctx.Response().Header().Set("ETag", handler.ETag(response.Version))
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// Patch function is a handler to patch todo
+// @Summary Patch todo
+// @Description Patch a todo with a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) against model.TodoPatchDocument. Fields can be cleared and tags edited one by one. Send the ETag of the todo in If-Match to only patch it when nobody changed it in between.
+// @Tags todo
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param If-Match header string false "ETag the todo must still have"
+// @Param patch body object true "Merge patch or JSON patch"
+// @Success 200 {object} model.Response[model.TodoResponse]
+// @Header 200 {string} ETag "Version of the patched todo"
+// @Failure 400 {object} model.Error
+// @Failure 409 {object} model.Error
+// @Failure 412 {object} model.Error
+// @Failure 415 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id} [patch]
+func (h *TodoHandlerImpl) Patch(ctx echo.Context) error {
+	request := &model.TodoPatchRequest{
+		ID: ctx.Param("id"),
+	}
+
+	mediaType, _, err := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || (mediaType != model.TodoPatchMerge && mediaType != model.TodoPatchJSON) {
+		ctx.Response().Header().Set("Accept-Patch", model.TodoPatchMerge+", "+model.TodoPatchJSON)
+		return handler.HandleError(ctx, http.StatusUnsupportedMediaType, handler.ErrUnsupportedMedia)
+	}
+	request.Format = mediaType
+
+	patch, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		h.Log.Errorf("failed to read request body: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+	request.Patch = patch
+
+	version, ok := handler.IfMatch(ctx.Request().Header.Get("If-Match"))
+	if !ok {
+		return handler.HandleError(ctx, http.StatusPreconditionFailed, handler.ErrPrecondition)
+	}
+	request.ExpectedVersion = version
+
+	response, err := h.Todo.Patch(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to patch todo: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
 			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
-		} else {
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		case err.Error() == "Precondition Failed":
+			return handler.HandleError(ctx, http.StatusPreconditionFailed, handler.ErrPrecondition)
+		default:
 			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
 		}
 	}
 
+	ctx.Response().Header().Set("ETag", handler.ETag(response.Version))
 	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
 }
 
+// Bulk function is a handler to run several todo operations at once
+// @Summary Bulk todo operations
+// @Description Run create, update, complete, delete and move operations in one transaction. Responds 207 when any item failed, atomic mode rolls every item back in that case.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param operations body model.TodoBulkRequest true "Operations"
+// @Success 200 {object} model.Response[model.TodoBulkResponse]
+// @Success 207 {object} model.Response[model.TodoBulkResponse]
+// @Failure 400 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/bulk [post]
+func (h *TodoHandlerImpl) Bulk(ctx echo.Context) error {
+	request := new(model.TodoBulkRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.Bulk(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to run bulk operations: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Unauthorized":
+			return handler.HandleError(ctx, http.StatusUnauthorized, handler.ErrorUnauthorized)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	status := http.StatusOK
+	if response.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	return ctx.JSON(status, model.NewResponse(response, nil))
+}
+
 // Delete function is a handler to delete todo
 // @Summary Delete todo
 // @Description Delete todo
@@ -132,6 +271,8 @@ func (h *TodoHandlerImpl) Update(ctx echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Todo ID"
+// @Param cascade query bool false "Delete subtasks too, otherwise they move up one level"
+// @Param permanent query bool false "Delete for good instead of moving to the trash"
 // @Success 200 {object} model.Response[model.TodoResponse]
 // @Failure 400 {object} model.Error
 // @Failure 500 {object} model.Error
@@ -162,6 +303,332 @@ func (h *TodoHandlerImpl) Delete(ctx echo.Context) error {
 	return ctx.JSON(http.StatusNoContent, nil)
 }
 
+// GetChildren function is a handler to list subtasks of a todo
+// @Summary List subtasks
+// @Description List direct subtasks of a todo
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Success 200 {object} model.Response[[]model.TodoResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/children [get]
+func (h *TodoHandlerImpl) GetChildren(ctx echo.Context) error {
+	request := new(model.TodoGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.GetChildren(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to list subtasks: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Skip function is a handler to skip the current occurrence of a repeating todo
+// @Summary Skip occurrence
+// @Description Move a repeating todo to its next occurrence without completing it
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Success 200 {object} model.Response[model.TodoResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/skip [post]
+func (h *TodoHandlerImpl) Skip(ctx echo.Context) error {
+	request := new(model.TodoGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.Skip(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to skip occurrence: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// Reorder function is a handler to move a todo within its list
+// @Summary Move todo within its list
+// @Description Place a todo right after the after todo, right before the before todo, or between the two. The anchors must be in the same list as the todo.
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param anchors body model.TodoReorderRequest true "Anchors"
+// @Success 200 {object} model.Response[model.TodoResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 409 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/move [post]
+func (h *TodoHandlerImpl) Reorder(ctx echo.Context) error {
+	request := new(model.TodoReorderRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.Reorder(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to reorder todo: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// EndSeries function is a handler to stop a todo from repeating
+// @Summary End series
+// @Description Remove the recurrence rule, the current occurrence stays as a regular todo
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Success 200 {object} model.Response[model.TodoResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/recurrence [delete]
+func (h *TodoHandlerImpl) EndSeries(ctx echo.Context) error {
+	request := new(model.TodoGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.EndSeries(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to end series: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// GetTrash function is a handler to list deleted todo
+// @Summary List trash
+// @Description List deleted todo that can still be restored, most recently deleted first
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param page query int false "Page"
+// @Param size query int false "Size"
+// @Success 200 {object} model.Response[[]model.TodoResponse]
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/trash [get]
+func (h *TodoHandlerImpl) GetTrash(ctx echo.Context) error {
+	request := new(model.TodoTrashRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.GetTrash(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to list trash: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Restore function is a handler to restore a deleted todo
+// @Summary Restore todo
+// @Description Restore a todo from the trash together with its deleted subtasks
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Success 200 {object} model.Response[model.TodoResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/restore [post]
+func (h *TodoHandlerImpl) Restore(ctx echo.Context) error {
+	request := new(model.TodoGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.Restore(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to restore todo: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// GetActivity function is a handler to list the history of a todo
+// @Summary Todo activity
+// @Description List who changed what on a todo and when, newest first
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param page query int false "Page"
+// @Param size query int false "Size"
+// @Success 200 {object} model.Response[[]model.TodoActivityResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/activity [get]
+func (h *TodoHandlerImpl) GetActivity(ctx echo.Context) error {
+	request := new(model.TodoActivityGetAllRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.GetActivity(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to get todo activity: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// Revert function is a handler to revert a todo to an earlier version
+// @Summary Revert todo
+// @Description Put a todo back to the state it had after an entry of its activity, a parent or project that can no longer take it is a conflict
+// @Tags todo
+// @Accept json
+// @Produce json
+// @Param id path string true "Todo ID"
+// @Param activity_id path string true "Activity ID"
+// @Success 200 {object} model.Response[model.TodoResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 409 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /todo/{id}/activity/{activity_id}/revert [post]
+func (h *TodoHandlerImpl) Revert(ctx echo.Context) error {
+	request := new(model.TodoRevertRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Todo.Revert(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to revert todo: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
 // GetAll function is a handler to list todo
 // @Summary List todo
 // @Description List todo
@@ -170,8 +637,19 @@ func (h *TodoHandlerImpl) Delete(ctx echo.Context) error {
 // @Produce json
 // @Param limit query int false "Limit"
 // @Param page query int false "Page"
-// @Param sort query string false "Sort"
+// @Param sort query string false "Sort, position is the order arranged by hand"
 // @Param order query string false "Order"
+// @Param priority query string false "Priority (none, low, medium, high, urgent)"
+// @Param tags query string false "Comma separated tag names"
+// @Param tag_mode query string false "Tag match mode (any, all)"
+// @Param project_id query string false "Project ID, or inbox for todos without a project"
+// @Param due query string false "Due filter (overdue, today)"
+// @Param due_before query string false "Due before (RFC3339)"
+// @Param due_after query string false "Due after (RFC3339)"
+// @Param filter query string false "Filter expression, e.g. done:false AND created_at>2026-01-01 AND title~\"report\""
+// @Param after query string false "Cursor of the page to read the todos after, replaces page"
+// @Param before query string false "Cursor of the page to read the todos before, replaces page"
+// @Param total query bool false "Count the matching todos, defaults to true with page and false with cursors"
 // @Success 200 {object} model.Response[[]model.TodoResponse]
 // @Failure 400 {object} model.Error
 // @Failure 500 {object} model.Error
@@ -188,6 +666,8 @@ func (h *TodoHandlerImpl) GetAll(ctx echo.Context) error {
 	if err != nil {
 		h.Log.Errorf("failed to list todo: %v", err)
 		switch {
+		case errors.Is(err, filter.ErrInvalidFilter):
+			return handler.HandleError(ctx, http.StatusBadRequest, err)
 		case err.Error() == "Bad Request":
 			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
 		case err.Error() == "Not Found":
@@ -202,15 +682,23 @@ func (h *TodoHandlerImpl) GetAll(ctx echo.Context) error {
 
 // Search function is a handler to search todo
 // @Summary Search todo
-// @Description Search todo
+// @Description Full-text search over todo, ranked by relevance with highlighted matches
 // @Tags todo
 // @Accept json
 // @Produce json
-// @Param title query string true "Title"
+// @Param q query string false "Full-text query, supports \"phrases\", prefix*, -exclusions and OR"
+// @Param title query string false "Deprecated, use q"
 // @Param page query int false "Page"
 // @Param size query int false "Size"
-// @Param sort query string false "Sort"
+// @Param sort query string false "Sort, defaults to rank when searching"
 // @Param order query string false "Order"
+// @Param tags query string false "Comma separated tag names"
+// @Param tag_mode query string false "Tag match mode (any, all)"
+// @Param project_id query string false "Project ID, or inbox for todos without a project"
+// @Param filter query string false "Filter expression, e.g. done:false AND created_at>2026-01-01 AND title~\"report\""
+// @Param after query string false "Cursor of the page to read the todos after, replaces page"
+// @Param before query string false "Cursor of the page to read the todos before, replaces page"
+// @Param total query bool false "Count the matching todos, defaults to true with page and false with cursors"
 // @Success 200 {object} model.Response[[]model.TodoResponse]
 // @Failure 400 {object} model.Error
 // @Failure 500 {object} model.Error
@@ -227,6 +715,8 @@ func (h *TodoHandlerImpl) Search(ctx echo.Context) error {
 	if err != nil {
 		h.Log.Errorf("failed to search todo: %v", err)
 		switch {
+		case errors.Is(err, filter.ErrInvalidFilter):
+			return handler.HandleError(ctx, http.StatusBadRequest, err)
 		case err.Error() == "Bad Request":
 			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
 		case err.Error() == "Not Found":
@@ -6,6 +6,12 @@ import (
 
 type UserHandler interface {
 	Register(ctx echo.Context) error
+	Verify(ctx echo.Context) error
+	ResendVerification(ctx echo.Context) error
 	Login(ctx echo.Context) error
 	Refresh(ctx echo.Context) error
+	Logout(ctx echo.Context) error
+	GetSessions(ctx echo.Context) error
+	RevokeSession(ctx echo.Context) error
+	RevokeSessions(ctx echo.Context) error
 }
@@ -23,7 +23,7 @@ func NewUserHandlerImpl(log *logrus.Logger, u user.UserUsecase) *UserHandlerImpl
 
 // Register function is a handler to register a new user
 // @Summary Register a new user
-// @Description Register a new user
+// @Description Register a new user, a link to verify the email is sent to it
 // @Tags user
 // @Accept json
 // @Produce json
@@ -55,15 +55,83 @@ func (h *UserHandlerImpl) Register(ctx echo.Context) error {
 	return ctx.JSON(http.StatusCreated, model.NewResponse(response, nil))
 }
 
+// Verify function is a handler to verify the email of a user
+// @Summary Verify email
+// @Description Verify the email of a user with the token of the link sent to it
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} model.Response[model.UserResponse]
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @Router /users/verify [get]
+func (h *UserHandlerImpl) Verify(ctx echo.Context) error {
+	request := new(model.VerifyEmailRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.User.Verify(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to verify user: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// ResendVerification function is a handler to send another verification link
+// @Summary Resend verification email
+// @Description Send another verification link to an email that is not verified yet, the answer is the same for unknown emails
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param user body model.ResendVerificationRequest true "User data"
+// @Success 202
+// @Failure 400 {object} model.Error
+// @Failure 500 {object} model.Error
+// @Router /users/verify/resend [post]
+func (h *UserHandlerImpl) ResendVerification(ctx echo.Context) error {
+	request := new(model.ResendVerificationRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	_, err := h.User.ResendVerification(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to resend verification: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusAccepted, nil)
+}
+
 // Login function is a handler to login a user
 // @Summary Login a user
-// @Description Login a user
+// @Description Login a user, workspace_id picks the default workspace of the tokens. Users who did not verify their email get 403 when verification is required.
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param user body model.LoginRequest true "User data"
 // @Success 200 {object} model.Response[model.UserResponse]
 // @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
 // @Failure 500 {object} model.Error
 // @Router /users/login [post]
 func (h *UserHandlerImpl) Login(ctx echo.Context) error {
@@ -72,6 +140,7 @@ func (h *UserHandlerImpl) Login(ctx echo.Context) error {
 		h.Log.Errorf("failed to bind request: %v", err)
 		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
 	}
+	request.UserAgent = ctx.Request().UserAgent()
 
 	response, err := h.User.Login(ctx.Request().Context(), request)
 	if err != nil {
@@ -81,6 +150,8 @@ func (h *UserHandlerImpl) Login(ctx echo.Context) error {
 			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
 		case err.Error() == "Unauthorized":
 			return handler.HandleError(ctx, http.StatusUnauthorized, handler.ErrorUnauthorized)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
 		default:
 			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
 		}
@@ -91,13 +162,15 @@ func (h *UserHandlerImpl) Login(ctx echo.Context) error {
 
 // Refresh function is a handler to refresh token
 // @Summary Refresh token
-// @Description Refresh token
+// @Description Trade a refresh token for a new pair, the old refresh token stops working. Presenting a refresh token that was already traded revokes its session. Users who did not verify their email get 403 when verification is required.
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param token body model.RefreshTokenRequest true "Refresh token data"
 // @Success 200 {object} model.Response[model.TokenResponse]
 // @Failure 400 {object} model.Error
+// @Failure 401 {object} model.Error
+// @Failure 403 {object} model.Error
 // @Failure 500 {object} model.Error
 // @Router /users/refresh [post]
 func (h *UserHandlerImpl) Refresh(ctx echo.Context) error {
@@ -107,7 +180,7 @@ func (h *UserHandlerImpl) Refresh(ctx echo.Context) error {
 		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
 	}
 
-	response, err := h.User.RefreshToken(request)
+	response, err := h.User.RefreshToken(ctx.Request().Context(), request)
 	if err != nil {
 		h.Log.Errorf("failed to refresh token: %v", err)
 		switch {
@@ -115,6 +188,8 @@ func (h *UserHandlerImpl) Refresh(ctx echo.Context) error {
 			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
 		case err.Error() == "Unauthorized":
 			return handler.HandleError(ctx, http.StatusUnauthorized, handler.ErrorUnauthorized)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
 		default:
 			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
 		}
@@ -122,3 +197,119 @@ func (h *UserHandlerImpl) Refresh(ctx echo.Context) error {
 
 	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
 }
+
+// Logout function is a handler to logout a user
+// @Summary Logout a user
+// @Description Revoke the session of the calling token, its refresh token and the calling access token stop working
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /users/logout [post]
+func (h *UserHandlerImpl) Logout(ctx echo.Context) error {
+	_, err := h.User.Logout(ctx.Request().Context())
+	if err != nil {
+		h.Log.Errorf("failed to logout user: %v", err)
+		return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
+
+// GetSessions function is a handler to list sessions
+// @Summary List sessions
+// @Description List the open sessions of the calling user, current marks the session of the calling token
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.Response[[]model.SessionResponse]
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /users/sessions [get]
+func (h *UserHandlerImpl) GetSessions(ctx echo.Context) error {
+	response, err := h.User.GetSessions(ctx.Request().Context())
+	if err != nil {
+		h.Log.Errorf("failed to get sessions: %v", err)
+		return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// RevokeSession function is a handler to revoke a session
+// @Summary Revoke session
+// @Description Revoke a session of the calling user, its refresh token stops working
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 204
+// @Failure 400 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /users/sessions/{id} [delete]
+func (h *UserHandlerImpl) RevokeSession(ctx echo.Context) error {
+	request := new(model.SessionDeleteRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	_, err := h.User.RevokeSession(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to revoke session: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
+
+// RevokeSessions function is a handler to revoke every session of a user
+// @Summary Revoke sessions
+// @Description Revoke every session of the calling user, or of the user in the path which needs an admin
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param id path string false "User ID"
+// @Success 204
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /users/sessions [delete]
+// @Router /users/{id}/sessions [delete]
+func (h *UserHandlerImpl) RevokeSessions(ctx echo.Context) error {
+	request := new(model.SessionRevokeRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	_, err := h.User.RevokeSessions(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to revoke sessions: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,17 @@
+package workspace
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+type WorkspaceHandler interface {
+	Create(ctx echo.Context) error
+	GetAll(ctx echo.Context) error
+	GetByID(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	GetMembers(ctx echo.Context) error
+	UpdateMember(ctx echo.Context) error
+	RemoveMember(ctx echo.Context) error
+	Invite(ctx echo.Context) error
+	AcceptInvite(ctx echo.Context) error
+}
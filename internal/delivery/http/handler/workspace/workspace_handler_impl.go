@@ -0,0 +1,357 @@
+package workspace
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/usecases/workspace"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type WorkspaceHandlerImpl struct {
+	Log       *logrus.Logger
+	Workspace workspace.WorkspaceUsecase
+}
+
+func NewWorkspaceHandlerImpl(log *logrus.Logger, w workspace.WorkspaceUsecase) *WorkspaceHandlerImpl {
+	return &WorkspaceHandlerImpl{
+		Log:       log,
+		Workspace: w,
+	}
+}
+
+// Create function is a handler to create a workspace
+// @Summary Create workspace
+// @Description Create a workspace with the calling user as its owner
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param workspace body model.WorkspaceCreateRequest true "Workspace data"
+// @Success 201 {object} model.Response[model.WorkspaceResponse]
+// @Failure 400 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces [post]
+func (h *WorkspaceHandlerImpl) Create(ctx echo.Context) error {
+	request := new(model.WorkspaceCreateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Workspace.Create(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to create workspace: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusCreated, model.NewResponse(response, nil))
+}
+
+// GetAll function is a handler to list workspaces
+// @Summary List workspaces
+// @Description List the workspaces the calling user is a member of, with their role in each
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.Response[[]model.WorkspaceResponse]
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces [get]
+func (h *WorkspaceHandlerImpl) GetAll(ctx echo.Context) error {
+	response, err := h.Workspace.GetAll(ctx.Request().Context())
+	if err != nil {
+		h.Log.Errorf("failed to get workspaces: %v", err)
+		return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetByID function is a handler to get a workspace
+// @Summary Get workspace
+// @Description Get a workspace the calling user is a member of
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Success 200 {object} model.Response[model.WorkspaceResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces/{id} [get]
+func (h *WorkspaceHandlerImpl) GetByID(ctx echo.Context) error {
+	request := new(model.WorkspaceGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Workspace.Get(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to get workspace: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// Update function is a handler to rename a workspace
+// @Summary Update workspace
+// @Description Rename a workspace, only its owner and admins may do so
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Param workspace body model.WorkspaceUpdateRequest true "Workspace data"
+// @Success 200 {object} model.Response[model.WorkspaceResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces/{id} [put]
+func (h *WorkspaceHandlerImpl) Update(ctx echo.Context) error {
+	request := new(model.WorkspaceUpdateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Workspace.Update(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to update workspace: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// GetMembers function is a handler to list the members of a workspace
+// @Summary List workspace members
+// @Description List the members of a workspace and their roles
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Success 200 {object} model.Response[[]model.WorkspaceMemberResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces/{id}/members [get]
+func (h *WorkspaceHandlerImpl) GetMembers(ctx echo.Context) error {
+	request := new(model.WorkspaceGetRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Workspace.GetMembers(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to get workspace members: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateMember function is a handler to change the role of a member
+// @Summary Update workspace member
+// @Description Change the role of a member, the owner changes anyone but themselves and admins change members and guests
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Param user_id path string true "User ID"
+// @Param member body model.WorkspaceMemberUpdateRequest true "Member data"
+// @Success 200 {object} model.Response[model.WorkspaceMemberResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces/{id}/members/{user_id} [put]
+func (h *WorkspaceHandlerImpl) UpdateMember(ctx echo.Context) error {
+	request := new(model.WorkspaceMemberUpdateRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Workspace.UpdateMember(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to update workspace member: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
+
+// RemoveMember function is a handler to remove a member from a workspace
+// @Summary Remove workspace member
+// @Description Remove a member together with the shares they hold in the workspace, members may remove themselves to leave
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces/{id}/members/{user_id} [delete]
+func (h *WorkspaceHandlerImpl) RemoveMember(ctx echo.Context) error {
+	request := new(model.WorkspaceMemberDeleteRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	_, err := h.Workspace.RemoveMember(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to remove workspace member: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
+
+// Invite function is a handler to invite a user to a workspace
+// @Summary Invite to workspace
+// @Description Invite an email address to a workspace, the token in the response is shown only once
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Param invite body model.WorkspaceInviteRequest true "Invite data"
+// @Success 201 {object} model.Response[model.WorkspaceInviteResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 409 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces/{id}/invites [post]
+func (h *WorkspaceHandlerImpl) Invite(ctx echo.Context) error {
+	request := new(model.WorkspaceInviteRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Workspace.Invite(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to invite to workspace: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusCreated, model.NewResponse(response, nil))
+}
+
+// AcceptInvite function is a handler to accept a workspace invite
+// @Summary Accept workspace invite
+// @Description Join the workspace of an invite addressed to the calling user's email
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param invite body model.WorkspaceInviteAcceptRequest true "Invite token"
+// @Success 200 {object} model.Response[model.WorkspaceResponse]
+// @Failure 400 {object} model.Error
+// @Failure 403 {object} model.Error
+// @Failure 404 {object} model.Error
+// @Failure 409 {object} model.Error
+// @Failure 500 {object} model.Error
+// @security ApiKeyAuth
+// @Router /workspaces/invites/accept [post]
+func (h *WorkspaceHandlerImpl) AcceptInvite(ctx echo.Context) error {
+	request := new(model.WorkspaceInviteAcceptRequest)
+	if err := ctx.Bind(request); err != nil {
+		h.Log.Errorf("failed to bind request: %v", err)
+		return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrorBindingRequest)
+	}
+
+	response, err := h.Workspace.AcceptInvite(ctx.Request().Context(), request)
+	if err != nil {
+		h.Log.Errorf("failed to accept workspace invite: %v", err)
+		switch {
+		case err.Error() == "Bad Request":
+			return handler.HandleError(ctx, http.StatusBadRequest, handler.ErrValidation)
+		case err.Error() == "Not Found":
+			return handler.HandleError(ctx, http.StatusNotFound, handler.ErrNotFound)
+		case err.Error() == "Forbidden":
+			return handler.HandleError(ctx, http.StatusForbidden, handler.ErrForbidden)
+		case err.Error() == "Conflict":
+			return handler.HandleError(ctx, http.StatusConflict, handler.ErrorConflict)
+		default:
+			return handler.HandleError(ctx, http.StatusInternalServerError, handler.ErrorInternalServer)
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, model.NewResponse(response, nil))
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"github.com/labstack/echo/v4"
 	"github.com/savioruz/mikti-task/internal/domain/model"
 	"github.com/savioruz/mikti-task/internal/platform/jwt"
@@ -11,7 +12,8 @@ import (
 
 const contextKey = "claims"
 
-func AuthMiddleware(jwtService jwt.JWTService) echo.MiddlewareFunc {
+// AuthMiddleware accepts a valid bearer token unless it was denied on logout
+func AuthMiddleware(jwtService jwt.JWTService, denylist jwt.Denylist) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			errMessage := func(message string) error {
@@ -28,11 +30,27 @@ func AuthMiddleware(jwtService jwt.JWTService) echo.MiddlewareFunc {
 				return errMessage("Invalid authorization header")
 			}
 
-			claims, err := jwtService.ValidateToken(bearerToken[1])
-			if err != nil {
+			claims, err := jwtService.ValidateToken(bearerToken[1], jwt.TokenTypeAccess)
+			switch {
+			case errors.Is(err, jwt.ErrTokenExpired):
+				return errMessage("Token has expired")
+			case errors.Is(err, jwt.ErrTokenType):
+				return errMessage("Invalid token type")
+			case err != nil:
 				return errMessage("Invalid token")
 			}
 
+			if claims.ID != "" {
+				// Failing closed, a token that cannot be checked is not trusted
+				denied, err := denylist.Contains(claims.ID)
+				if err != nil {
+					return errMessage("Unable to verify token")
+				}
+				if denied {
+					return errMessage("Token has been revoked")
+				}
+			}
+
 			c.Set(contextKey, claims)
 
 			ctx := context.WithValue(c.Request().Context(), contextKey, claims)
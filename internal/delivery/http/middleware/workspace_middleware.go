@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/platform/jwt"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+	"github.com/savioruz/mikti-task/internal/usecases/workspace"
+	"net/http"
+)
+
+// WorkspaceMiddleware selects the workspace of the request from the X-Workspace-ID header, the token's claim or
+// the user's personal workspace, in that order. It must run after AuthMiddleware.
+func WorkspaceMiddleware(workspaceUC workspace.WorkspaceUsecase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := c.Get(contextKey).(*jwt.JWTClaims)
+			if !ok || claims == nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, model.NewErrorResponse[any](http.StatusUnauthorized, "Missing token claims"))
+			}
+
+			workspaceID := c.Request().Header.Get(tenant.Header)
+			if workspaceID == "" {
+				workspaceID = claims.WorkspaceID
+			}
+			if workspaceID == "" {
+				workspaceID = claims.UserID
+			}
+
+			active, err := workspaceUC.Enter(c.Request().Context(), workspaceID)
+			if err != nil {
+				switch err.Error() {
+				case "Bad Request":
+					return echo.NewHTTPError(http.StatusBadRequest, model.NewErrorResponse[any](http.StatusBadRequest, "Invalid workspace"))
+				case "Forbidden", "Not Found":
+					return echo.NewHTTPError(http.StatusForbidden, model.NewErrorResponse[any](http.StatusForbidden, "Not a member of the workspace"))
+				default:
+					return echo.NewHTTPError(http.StatusInternalServerError, model.NewErrorResponse[any](http.StatusInternalServerError, "Failed to select workspace"))
+				}
+			}
+
+			c.SetRequest(c.Request().WithContext(tenant.WithWorkspace(c.Request().Context(), *active)))
+
+			return next(c)
+		}
+	}
+}
@@ -4,22 +4,43 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/savioruz/mikti-task/internal/delivery/graph/handler"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/attachment"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/comment"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/jwks"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/project"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/share"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/tag"
 	"github.com/savioruz/mikti-task/internal/delivery/http/handler/todo"
 	"github.com/savioruz/mikti-task/internal/delivery/http/handler/user"
+	"github.com/savioruz/mikti-task/internal/delivery/http/handler/workspace"
 	swagger "github.com/swaggo/echo-swagger"
 )
 
 type Config struct {
-	App            *echo.Echo
-	GraphQLHandler *handler.GraphQLHandler
-	TodoHandler    *todo.TodoHandlerImpl
-	UserHandler    *user.UserHandlerImpl
-	AuthMiddleware echo.MiddlewareFunc
+	App               *echo.Echo
+	GraphQLHandler    *handler.GraphQLHandler
+	TodoHandler       *todo.TodoHandlerImpl
+	UserHandler       *user.UserHandlerImpl
+	TagHandler        *tag.TagHandlerImpl
+	ProjectHandler    *project.ProjectHandlerImpl
+	AttachmentHandler *attachment.AttachmentHandlerImpl
+	CommentHandler    *comment.CommentHandlerImpl
+	// TodoShareHandler and ProjectShareHandler serve the same endpoints for todos and projects
+	TodoShareHandler    *share.ShareHandlerImpl
+	ProjectShareHandler *share.ShareHandlerImpl
+	WorkspaceHandler    *workspace.WorkspaceHandlerImpl
+	JWKSHandler         *jwks.JWKSHandlerImpl
+	AuthMiddleware      echo.MiddlewareFunc
+	// WorkspaceMiddleware selects the workspace of a request, every route reading todos or projects needs it
+	WorkspaceMiddleware echo.MiddlewareFunc
 }
 
 func (c *Config) Setup() {
 	c.publicRoutes()
 	c.protectedRoutes()
+	c.workspaceRoutes()
+	c.sessionRoutes()
+	c.wellKnownRoutes()
 	c.graphqlRoutes()
 	c.swaggerRoutes()
 	c.App.Use(middleware.Recover())
@@ -29,6 +50,8 @@ func (c *Config) publicRoutes() {
 	g := c.App.Group("/api/v1")
 	g.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(30)))
 	g.POST("/users", c.UserHandler.Register)
+	g.GET("/users/verify", c.UserHandler.Verify)
+	g.POST("/users/verify/resend", c.UserHandler.ResendVerification)
 	g.POST("/users/login", c.UserHandler.Login)
 	g.POST("/users/refresh", c.UserHandler.Refresh)
 }
@@ -37,17 +60,86 @@ func (c *Config) protectedRoutes() {
 	g := c.App.Group("/api/v1")
 	g.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(30)))
 	g.Use(c.AuthMiddleware)
+	g.Use(c.WorkspaceMiddleware)
 	g.POST("/todo", c.TodoHandler.Create)
 	g.GET("/todo", c.TodoHandler.GetAll)
+	g.POST("/todo/bulk", c.TodoHandler.Bulk)
 	g.GET("/todo/search", c.TodoHandler.Search)
+	g.GET("/todo/trash", c.TodoHandler.GetTrash)
 	g.GET("/todo/:id", c.TodoHandler.GetByID)
+	g.GET("/todo/:id/children", c.TodoHandler.GetChildren)
+	g.POST("/todo/:id/skip", c.TodoHandler.Skip)
+	g.POST("/todo/:id/move", c.TodoHandler.Reorder)
+	g.POST("/todo/:id/restore", c.TodoHandler.Restore)
+	g.DELETE("/todo/:id/recurrence", c.TodoHandler.EndSeries)
+	g.GET("/todo/:id/activity", c.TodoHandler.GetActivity)
+	g.POST("/todo/:id/activity/:activity_id/revert", c.TodoHandler.Revert)
 	g.PUT("/todo/:id", c.TodoHandler.Update)
+	g.PATCH("/todo/:id", c.TodoHandler.Patch)
 	g.DELETE("/todo/:id", c.TodoHandler.Delete)
+	g.POST("/todo/:id/attachments", c.AttachmentHandler.Upload)
+	g.GET("/todo/:id/attachments", c.AttachmentHandler.GetAll)
+	g.GET("/todo/:id/attachments/:attachment_id", c.AttachmentHandler.Download)
+	g.DELETE("/todo/:id/attachments/:attachment_id", c.AttachmentHandler.Delete)
+	g.POST("/todo/:id/comments", c.CommentHandler.Create)
+	g.GET("/todo/:id/comments", c.CommentHandler.GetAll)
+	g.PUT("/todo/:id/comments/:comment_id", c.CommentHandler.Update)
+	g.DELETE("/todo/:id/comments/:comment_id", c.CommentHandler.Delete)
+	g.POST("/todo/:id/shares", c.TodoShareHandler.Create)
+	g.GET("/todo/:id/shares", c.TodoShareHandler.GetAll)
+	g.DELETE("/todo/:id/shares/:share_id", c.TodoShareHandler.Delete)
+	g.POST("/tags", c.TagHandler.Create)
+	g.GET("/tags", c.TagHandler.GetAll)
+	g.GET("/tags/:id", c.TagHandler.GetByID)
+	g.PUT("/tags/:id", c.TagHandler.Update)
+	g.DELETE("/tags/:id", c.TagHandler.Delete)
+	g.POST("/projects", c.ProjectHandler.Create)
+	g.GET("/projects", c.ProjectHandler.GetAll)
+	g.GET("/projects/:id", c.ProjectHandler.GetByID)
+	g.PUT("/projects/:id", c.ProjectHandler.Update)
+	g.DELETE("/projects/:id", c.ProjectHandler.Delete)
+	g.POST("/projects/:id/shares", c.ProjectShareHandler.Create)
+	g.GET("/projects/:id/shares", c.ProjectShareHandler.GetAll)
+	g.DELETE("/projects/:id/shares/:share_id", c.ProjectShareHandler.Delete)
+}
+
+// workspaceRoutes manage workspaces themselves, they work without an active workspace
+func (c *Config) workspaceRoutes() {
+	g := c.App.Group("/api/v1")
+	g.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(30)))
+	g.Use(c.AuthMiddleware)
+	g.POST("/workspaces", c.WorkspaceHandler.Create)
+	g.GET("/workspaces", c.WorkspaceHandler.GetAll)
+	g.POST("/workspaces/invites/accept", c.WorkspaceHandler.AcceptInvite)
+	g.GET("/workspaces/:id", c.WorkspaceHandler.GetByID)
+	g.PUT("/workspaces/:id", c.WorkspaceHandler.Update)
+	g.GET("/workspaces/:id/members", c.WorkspaceHandler.GetMembers)
+	g.PUT("/workspaces/:id/members/:user_id", c.WorkspaceHandler.UpdateMember)
+	g.DELETE("/workspaces/:id/members/:user_id", c.WorkspaceHandler.RemoveMember)
+	g.POST("/workspaces/:id/invites", c.WorkspaceHandler.Invite)
+}
+
+// sessionRoutes manage the logins of users, they work without an active workspace
+func (c *Config) sessionRoutes() {
+	g := c.App.Group("/api/v1")
+	g.Use(middleware.RateLimiter(middleware.NewRateLimiterMemoryStore(30)))
+	g.Use(c.AuthMiddleware)
+	g.POST("/users/logout", c.UserHandler.Logout)
+	g.GET("/users/sessions", c.UserHandler.GetSessions)
+	g.DELETE("/users/sessions", c.UserHandler.RevokeSessions)
+	g.DELETE("/users/sessions/:id", c.UserHandler.RevokeSession)
+	g.DELETE("/users/:id/sessions", c.UserHandler.RevokeSessions)
+}
+
+// wellKnownRoutes let other services discover how to verify our tokens
+func (c *Config) wellKnownRoutes() {
+	c.App.GET("/.well-known/jwks.json", c.JWKSHandler.Keys)
 }
 
 func (c *Config) graphqlRoutes() {
 	g := c.App.Group("/api/v1/graphql")
 	g.Use(c.AuthMiddleware)
+	g.Use(c.WorkspaceMiddleware)
 	g.POST("", c.GraphQLHandler.GraphQLHandler)
 	c.App.GET("/playground", c.GraphQLHandler.PlaygroundHandler)
 }
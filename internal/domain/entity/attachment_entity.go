@@ -0,0 +1,15 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Attachment struct {
+	ID          string `json:"id" gorm:"primary_key"`
+	TodoID      string `json:"todo_id" gorm:"not null"`
+	Filename    string `json:"filename" gorm:"not null"`
+	ContentType string `json:"content_type" gorm:"not null"`
+	Size        int64  `json:"size" gorm:"not null"`
+	// StorageKey locates the file in the storage backend
+	StorageKey string `json:"storage_key" gorm:"not null"`
+	UserID     string `json:"user_id" gorm:"not null"`
+	gorm.Model
+}
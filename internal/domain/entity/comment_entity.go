@@ -0,0 +1,14 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Comment struct {
+	ID     string `json:"id" gorm:"primary_key"`
+	TodoID string `json:"todo_id" gorm:"not null"`
+	// UserID is the author of the comment
+	UserID string `json:"user_id" gorm:"not null"`
+	Body   string `json:"body" gorm:"not null"`
+	// Edited is set once the body was changed after posting
+	Edited bool `json:"edited" gorm:"not null;default:false"`
+	gorm.Model
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Project struct {
+	ID       string  `json:"id" gorm:"primary_key"`
+	Name     string  `json:"name" gorm:"not null"`
+	Color    *string `json:"color"`
+	Archived bool    `json:"archived" gorm:"not null;default:false"`
+	UserID   string  `json:"user_id" gorm:"not null"`
+	User     User    `json:"user" gorm:"foreignKey:UserID"`
+	// WorkspaceID is set from the active workspace when the project is created
+	WorkspaceID string `json:"workspace_id" gorm:"not null"`
+	gorm.Model
+}
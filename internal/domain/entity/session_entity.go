@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Session is a login and the family of refresh tokens rotated from it, only the ID of the latest token is valid
+type Session struct {
+	ID         string     `json:"id" gorm:"primary_key"`
+	UserID     string     `json:"user_id" gorm:"not null"`
+	User       User       `json:"user" gorm:"foreignKey:UserID"`
+	TokenID    string     `json:"-" gorm:"not null"`
+	UserAgent  *string    `json:"user_agent"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	LastUsedAt time.Time  `json:"last_used_at" gorm:"not null"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	gorm.Model
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "gorm.io/gorm"
+
+// Share grants a user access to a todo or a project owned by someone else
+type Share struct {
+	ID           string `json:"id" gorm:"primary_key"`
+	ResourceType string `json:"resource_type" gorm:"not null"`
+	ResourceID   string `json:"resource_id" gorm:"not null"`
+	// UserID is the recipient of the grant
+	UserID    string `json:"user_id" gorm:"not null"`
+	User      User   `json:"user" gorm:"foreignKey:UserID"`
+	Role      string `json:"role" gorm:"not null"`
+	GrantedBy string `json:"granted_by" gorm:"not null"`
+	gorm.Model
+}
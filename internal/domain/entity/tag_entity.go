@@ -0,0 +1,11 @@
+package entity
+
+import "gorm.io/gorm"
+
+type Tag struct {
+	ID     string `json:"id" gorm:"primary_key"`
+	Name   string `json:"name" gorm:"not null"`
+	UserID string `json:"user_id" gorm:"not null"`
+	User   User   `json:"user" gorm:"foreignKey:UserID"`
+	gorm.Model
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// TodoActivity is one entry of a todo's history, entries are only ever added
+type TodoActivity struct {
+	ID     string `json:"id" gorm:"primary_key"`
+	TodoID string `json:"todo_id" gorm:"not null"`
+	// UserID is the user who made the change
+	UserID string `json:"user_id" gorm:"not null"`
+	Action string `json:"action" gorm:"not null"`
+	// Changes is a JSON list of the fields that changed with their values before and after
+	Changes string `json:"changes" gorm:"type:jsonb;not null"`
+	// Snapshot is the JSON state of the todo after the change, it is what a revert goes back to
+	Snapshot  string    `json:"snapshot" gorm:"type:jsonb;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
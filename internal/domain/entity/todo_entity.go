@@ -1,12 +1,42 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"time"
+)
 
 type Todo struct {
-	ID     string `json:"id" gorm:"primary_key"`
-	Title  string `json:"title" gorm:"not null"`
-	Done   bool   `json:"done" gorm:"not null"`
-	UserID string `json:"user_id" gorm:"not null"`
-	User   User   `json:"user" gorm:"foreignKey:UserID"`
+	ID    string `json:"id" gorm:"primary_key"`
+	Title string `json:"title" gorm:"not null"`
+	// Description is free form Markdown
+	Description *string    `json:"description"`
+	Done        bool       `json:"done" gorm:"not null"`
+	Priority    int        `json:"priority" gorm:"not null;default:0"`
+	DueAt       *time.Time `json:"due_at"`
+	StartAt     *time.Time `json:"start_at"`
+	ParentID    *string    `json:"parent_id"`
+	ProjectID   *string    `json:"project_id"`
+	// Recurrence is the RRULE of a repeating todo, only the open occurrence of a series carries it
+	Recurrence      *string    `json:"recurrence"`
+	RecurrenceStart *time.Time `json:"recurrence_start"`
+	RecurrenceTZ    *string    `json:"recurrence_tz"`
+	SeriesID        *string    `json:"series_id"`
+	UserID          string     `json:"user_id" gorm:"not null"`
+	User            User       `json:"user" gorm:"foreignKey:UserID"`
+	WorkspaceID     string     `json:"workspace_id" gorm:"not null"`
+	Tags            []Tag      `json:"tags" gorm:"many2many:todo_tags"`
+	// Position orders the todo by hand among the others in its list, smaller comes first
+	Position float64 `json:"position" gorm:"not null;default:0"`
+	// Version is bumped on every write, updates only go through against the version they read
+	Version int64 `json:"version" gorm:"not null;default:1"`
+	// Read-only rollups of the direct children, selected by the repository
+	ChildCount     int64 `json:"child_count" gorm:"->;-:migration"`
+	ChildDoneCount int64 `json:"child_done_count" gorm:"->;-:migration"`
+	// Highlight is the title with search matches marked, only selected by full-text search
+	Highlight *string `json:"highlight" gorm:"->;-:migration"`
+	// DescriptionHighlight is a snippet of the description around the search matches, marked the same way
+	DescriptionHighlight *string `json:"description_highlight" gorm:"->;-:migration"`
+	// SearchRank is how well the todo matches the full-text query, read for search cursors
+	SearchRank *float64 `json:"search_rank" gorm:"->;-:migration"`
 	gorm.Model
 }
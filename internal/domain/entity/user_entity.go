@@ -1,6 +1,9 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"time"
+)
 
 type User struct {
 	ID       string `json:"id" gorm:"primary_key"`
@@ -8,5 +11,7 @@ type User struct {
 	Password string `json:"password" gorm:"not null"`
 	Role     string `json:"role" gorm:"not null"`
 	Status   bool   `json:"status" gorm:"not null"`
+	// EmailVerifiedAt is set once the user followed the link sent to their email
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
 	gorm.Model
 }
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Workspace groups todos and projects, every user starts with a personal workspace sharing their ID
+type Workspace struct {
+	ID       string `json:"id" gorm:"primary_key"`
+	Name     string `json:"name" gorm:"not null"`
+	Personal bool   `json:"personal" gorm:"not null;default:false"`
+	gorm.Model
+}
+
+type WorkspaceMember struct {
+	ID          string    `json:"id" gorm:"primary_key"`
+	WorkspaceID string    `json:"workspace_id" gorm:"not null"`
+	Workspace   Workspace `json:"workspace" gorm:"foreignKey:WorkspaceID"`
+	UserID      string    `json:"user_id" gorm:"not null"`
+	User        User      `json:"user" gorm:"foreignKey:UserID"`
+	Role        string    `json:"role" gorm:"not null"`
+	gorm.Model
+}
+
+// WorkspaceInvite lets the holder of the token join a workspace, only a hash of the token is kept
+type WorkspaceInvite struct {
+	ID          string     `json:"id" gorm:"primary_key"`
+	WorkspaceID string     `json:"workspace_id" gorm:"not null"`
+	Email       string     `json:"email" gorm:"not null"`
+	Role        string     `json:"role" gorm:"not null"`
+	TokenHash   string     `json:"-" gorm:"not null"`
+	InvitedBy   string     `json:"invited_by" gorm:"not null"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	gorm.Model
+}
@@ -0,0 +1,31 @@
+package model
+
+import "io"
+
+type AttachmentResponse struct {
+	ID          string  `json:"id"`
+	TodoID      string  `json:"todo_id"`
+	UserID      *string `json:"user_id,omitempty"`
+	Filename    string  `json:"filename"`
+	ContentType string  `json:"content_type"`
+	Size        int64   `json:"size"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+type AttachmentUploadRequest struct {
+	TodoID   string `param:"id" validate:"required,uuid"`
+	Filename string `validate:"required,lte=255"`
+	Size     int64
+	Body     io.Reader `validate:"required"`
+}
+
+type AttachmentGetRequest struct {
+	TodoID string `param:"id" validate:"required,uuid"`
+	ID     string `param:"attachment_id" validate:"required,uuid"`
+}
+
+// AttachmentDownload is an attachment with its opened content, the caller closes the body
+type AttachmentDownload struct {
+	Attachment *AttachmentResponse
+	Body       io.ReadCloser
+}
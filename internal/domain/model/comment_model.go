@@ -0,0 +1,32 @@
+package model
+
+type CommentResponse struct {
+	ID     string `json:"id"`
+	TodoID string `json:"todo_id"`
+	// AuthorID is always shown, a thread is read by everyone with access to the todo
+	AuthorID  string `json:"author_id"`
+	Body      string `json:"body"`
+	Edited    bool   `json:"edited"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type CommentCreateRequest struct {
+	TodoID string `param:"id" validate:"required,uuid"`
+	Body   string `json:"body" validate:"required,gte=1,lte=5000"`
+}
+
+type CommentGetAllRequest struct {
+	TodoID string `param:"id" validate:"required,uuid"`
+	Page   int    `query:"page" validate:"numeric"`
+	Size   int    `query:"size" validate:"numeric,lte=100"`
+}
+
+type CommentGetRequest struct {
+	TodoID string `param:"id" validate:"required,uuid"`
+	ID     string `param:"comment_id" validate:"required,uuid"`
+}
+
+type CommentUpdateRequest struct {
+	Body string `json:"body" validate:"required,gte=1,lte=5000"`
+}
@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+func AttachmentToResponse(attachment *entity.Attachment, isAdmin bool) *model.AttachmentResponse {
+	response := &model.AttachmentResponse{
+		ID:          attachment.ID,
+		TodoID:      attachment.TodoID,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt.String(),
+	}
+
+	if isAdmin {
+		response.UserID = &attachment.UserID
+	}
+
+	return response
+}
+
+func AttachmentsToResponses(attachments []entity.Attachment, isAdmin bool) []*model.AttachmentResponse {
+	attachmentResponses := make([]*model.AttachmentResponse, len(attachments))
+	for i := range attachments {
+		attachmentResponses[i] = AttachmentToResponse(&attachments[i], isAdmin)
+	}
+	return attachmentResponses
+}
@@ -0,0 +1,35 @@
+package converter
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+func CommentToResponse(comment *entity.Comment) *model.CommentResponse {
+	return &model.CommentResponse{
+		ID:        comment.ID,
+		TodoID:    comment.TodoID,
+		AuthorID:  comment.UserID,
+		Body:      comment.Body,
+		Edited:    comment.Edited,
+		CreatedAt: comment.CreatedAt.String(),
+		UpdatedAt: comment.UpdatedAt.String(),
+	}
+}
+
+func CommentsToPaginatedResponse(comments []entity.Comment, totalItems int64, page, size int) *model.Response[[]*model.CommentResponse] {
+	commentResponses := make([]*model.CommentResponse, len(comments))
+	for i := range comments {
+		commentResponses[i] = CommentToResponse(&comments[i])
+	}
+
+	total := int(totalItems)
+	totalPages := (total + size - 1) / size
+
+	return model.NewResponse(commentResponses, &model.PageMetadata{
+		Page:       page,
+		Size:       size,
+		TotalItems: &total,
+		TotalPages: &totalPages,
+	})
+}
@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+func ProjectToResponse(project *entity.Project, isAdmin bool) *model.ProjectResponse {
+	response := &model.ProjectResponse{
+		ID:        project.ID,
+		Name:      project.Name,
+		Color:     project.Color,
+		Archived:  project.Archived,
+		CreatedAt: project.CreatedAt.String(),
+		UpdatedAt: project.UpdatedAt.String(),
+	}
+
+	if isAdmin {
+		response.UserID = &project.UserID
+	}
+
+	return response
+}
+
+func ProjectsToResponses(projects []entity.Project, isAdmin bool) []*model.ProjectResponse {
+	projectResponses := make([]*model.ProjectResponse, len(projects))
+	for i := range projects {
+		projectResponses[i] = ProjectToResponse(&projects[i], isAdmin)
+	}
+	return projectResponses
+}
@@ -0,0 +1,28 @@
+package converter
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+func ShareToResponse(share *entity.Share) *model.ShareResponse {
+	return &model.ShareResponse{
+		ID:           share.ID,
+		ResourceType: share.ResourceType,
+		ResourceID:   share.ResourceID,
+		UserID:       share.UserID,
+		Email:        share.User.Email,
+		Role:         share.Role,
+		GrantedBy:    share.GrantedBy,
+		CreatedAt:    share.CreatedAt.String(),
+		UpdatedAt:    share.UpdatedAt.String(),
+	}
+}
+
+func SharesToResponses(shares []entity.Share) []*model.ShareResponse {
+	shareResponses := make([]*model.ShareResponse, len(shares))
+	for i := range shares {
+		shareResponses[i] = ShareToResponse(&shares[i])
+	}
+	return shareResponses
+}
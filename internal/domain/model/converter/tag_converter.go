@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+func TagToResponse(tag *entity.Tag) *model.TagResponse {
+	return &model.TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt.String(),
+		UpdatedAt: tag.UpdatedAt.String(),
+	}
+}
+
+func TagsToResponses(tags []entity.Tag) []*model.TagResponse {
+	tagResponses := make([]*model.TagResponse, len(tags))
+	for i := range tags {
+		tagResponses[i] = TagToResponse(&tags[i])
+	}
+	return tagResponses
+}
@@ -0,0 +1,83 @@
+package converter
+
+import (
+	"encoding/json"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"sort"
+	"time"
+)
+
+// TodoToSnapshot captures the fields of a todo that its history tracks
+func TodoToSnapshot(todo *entity.Todo) *model.TodoSnapshot {
+	tags := make([]string, len(todo.Tags))
+	for i := range todo.Tags {
+		tags[i] = todo.Tags[i].Name
+	}
+	sort.Strings(tags)
+
+	return &model.TodoSnapshot{
+		Title:       todo.Title,
+		Description: todo.Description,
+		Done:        todo.Done,
+		Priority:    PriorityFromLevel(todo.Priority),
+		DueAt:       snapshotTime(todo.DueAt),
+		StartAt:     snapshotTime(todo.StartAt),
+		ParentID:    todo.ParentID,
+		ProjectID:   todo.ProjectID,
+		Recurrence:  todo.Recurrence,
+		Timezone:    todo.RecurrenceTZ,
+		Tags:        tags,
+	}
+}
+
+// TodoToPatchDocument is the document patches to the todo are applied to, it carries the same fields as a snapshot
+func TodoToPatchDocument(todo *entity.Todo) *model.TodoPatchDocument {
+	return (*model.TodoPatchDocument)(TodoToSnapshot(todo))
+}
+
+// snapshotTime stores times the way the database returns them, so an unchanged time never shows up as a change
+func snapshotTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	normalized := t.UTC().Truncate(time.Microsecond)
+	return &normalized
+}
+
+func TodoActivityToResponse(activity *entity.TodoActivity) *model.TodoActivityResponse {
+	response := &model.TodoActivityResponse{
+		ID:        activity.ID,
+		TodoID:    activity.TodoID,
+		ActorID:   activity.UserID,
+		Action:    activity.Action,
+		Changes:   []*model.TodoActivityChange{},
+		CreatedAt: activity.CreatedAt.String(),
+	}
+
+	// Both columns are written by the usecase, a value that does not parse is left out rather than failing the list
+	_ = json.Unmarshal([]byte(activity.Changes), &response.Changes)
+	snapshot := &model.TodoSnapshot{}
+	if err := json.Unmarshal([]byte(activity.Snapshot), snapshot); err == nil {
+		response.Snapshot = snapshot
+	}
+
+	return response
+}
+
+func TodoActivitiesToPaginatedResponse(activities []entity.TodoActivity, totalItems int64, page, size int) *model.Response[[]*model.TodoActivityResponse] {
+	activityResponses := make([]*model.TodoActivityResponse, len(activities))
+	for i := range activities {
+		activityResponses[i] = TodoActivityToResponse(&activities[i])
+	}
+
+	total := int(totalItems)
+	totalPages := (total + size - 1) / size
+
+	return model.NewResponse(activityResponses, &model.PageMetadata{
+		Page:       page,
+		Size:       size,
+		TotalItems: &total,
+		TotalPages: &totalPages,
+	})
+}
@@ -3,24 +3,75 @@ package converter
 import (
 	"github.com/savioruz/mikti-task/internal/domain/entity"
 	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/platform/markdown"
 )
 
 func TodoToResponse(todo *entity.Todo, isAdmin bool) *model.TodoResponse {
 	response := &model.TodoResponse{
-		ID:        todo.ID,
-		Title:     todo.Title,
-		Done:      todo.Done,
-		CreatedAt: todo.CreatedAt.String(),
-		UpdatedAt: todo.UpdatedAt.String(),
+		ID:                   todo.ID,
+		Title:                todo.Title,
+		Description:          todo.Description,
+		Done:                 todo.Done,
+		Priority:             PriorityFromLevel(todo.Priority),
+		DueAt:                todo.DueAt,
+		StartAt:              todo.StartAt,
+		ParentID:             todo.ParentID,
+		ProjectID:            todo.ProjectID,
+		Recurrence:           todo.Recurrence,
+		Timezone:             todo.RecurrenceTZ,
+		SeriesID:             todo.SeriesID,
+		Highlight:            todo.Highlight,
+		DescriptionHighlight: todo.DescriptionHighlight,
+		Tags:                 TagsToResponses(todo.Tags),
+		Position:             todo.Position,
+		Version:              todo.Version,
+		CreatedAt:            todo.CreatedAt.String(),
+		UpdatedAt:            todo.UpdatedAt.String(),
 	}
 
 	if isAdmin {
 		response.UserID = &todo.UserID
 	}
 
+	if todo.Description != nil && *todo.Description != "" {
+		if html, err := markdown.Render(*todo.Description); err == nil {
+			response.DescriptionHTML = &html
+		}
+	}
+
+	if todo.DeletedAt.Valid {
+		response.DeletedAt = &todo.DeletedAt.Time
+	}
+
+	if todo.ChildCount > 0 {
+		response.Progress = &model.TodoProgress{
+			Total:   int(todo.ChildCount),
+			Done:    int(todo.ChildDoneCount),
+			Percent: int(todo.ChildDoneCount * 100 / todo.ChildCount),
+		}
+	}
+
 	return response
 }
 
+// PriorityToLevel maps a priority name to its stored level, unknown names map to none
+func PriorityToLevel(priority string) int {
+	for level, name := range model.TodoPriorities {
+		if name == priority {
+			return level
+		}
+	}
+	return 0
+}
+
+// PriorityFromLevel maps a stored level back to its priority name
+func PriorityFromLevel(level int) string {
+	if level < 0 || level >= len(model.TodoPriorities) {
+		return model.TodoPriorityNone
+	}
+	return model.TodoPriorities[level]
+}
+
 func TodosToResponses(todos []entity.Todo, isAdmin bool) []*model.TodoResponse {
 	todoResponses := make([]*model.TodoResponse, len(todos))
 	for i := range todos {
@@ -33,10 +84,30 @@ func TodosToPaginatedResponse(todos []entity.Todo, totalItems int64, page, size
 	todoResponses := TodosToResponses(todos, isAdmin)
 	totalPages := (int(totalItems) + size - 1) / size
 
+	total := int(totalItems)
+
 	return model.NewResponse(todoResponses, &model.PageMetadata{
 		Page:       page,
 		Size:       size,
-		TotalItems: int(totalItems),
-		TotalPages: totalPages,
+		TotalItems: &total,
+		TotalPages: &totalPages,
 	})
 }
+
+// TodosToPageResponse builds a list response from a page read by number or cursor, page is zero for cursors
+func TodosToPageResponse(todos []entity.Todo, todoPage *model.TodoPage, page, size int, isAdmin bool) *model.Response[[]*model.TodoResponse] {
+	paging := &model.PageMetadata{
+		Page:       page,
+		Size:       size,
+		NextCursor: todoPage.NextCursor,
+		PrevCursor: todoPage.PrevCursor,
+	}
+	if todoPage.TotalItems != nil {
+		totalItems := int(*todoPage.TotalItems)
+		totalPages := (totalItems + size - 1) / size
+		paging.TotalItems = &totalItems
+		paging.TotalPages = &totalPages
+	}
+
+	return model.NewResponse(TodosToResponses(todos, isAdmin), paging)
+}
@@ -7,12 +7,13 @@ import (
 
 func UserToResponse(user *entity.User) *model.UserResponse {
 	return &model.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Role:      user.Role,
-		Status:    user.Status,
-		CreatedAt: user.CreatedAt.String(),
-		UpdatedAt: user.UpdatedAt.String(),
+		ID:              user.ID,
+		Email:           user.Email,
+		Role:            user.Role,
+		Status:          user.Status,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt.String(),
+		UpdatedAt:       user.UpdatedAt.String(),
 	}
 }
 
@@ -22,3 +23,22 @@ func LoginToTokenResponse(accessToken, refreshToken string) *model.TokenResponse
 		RefreshToken: refreshToken,
 	}
 }
+
+func SessionToResponse(session *entity.Session, current bool) *model.SessionResponse {
+	return &model.SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		Current:    current,
+		LastUsedAt: session.LastUsedAt.String(),
+		ExpiresAt:  session.ExpiresAt.String(),
+		CreatedAt:  session.CreatedAt.String(),
+	}
+}
+
+func SessionsToResponses(sessions []entity.Session, currentID string) []*model.SessionResponse {
+	sessionResponses := make([]*model.SessionResponse, len(sessions))
+	for i := range sessions {
+		sessionResponses[i] = SessionToResponse(&sessions[i], sessions[i].ID == currentID)
+	}
+	return sessionResponses
+}
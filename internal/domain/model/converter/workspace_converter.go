@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+func WorkspaceToResponse(workspace *entity.Workspace, role string) *model.WorkspaceResponse {
+	return &model.WorkspaceResponse{
+		ID:        workspace.ID,
+		Name:      workspace.Name,
+		Personal:  workspace.Personal,
+		Role:      role,
+		CreatedAt: workspace.CreatedAt.String(),
+		UpdatedAt: workspace.UpdatedAt.String(),
+	}
+}
+
+func WorkspaceMemberToResponse(member *entity.WorkspaceMember) *model.WorkspaceMemberResponse {
+	return &model.WorkspaceMemberResponse{
+		WorkspaceID: member.WorkspaceID,
+		UserID:      member.UserID,
+		Email:       member.User.Email,
+		Role:        member.Role,
+		CreatedAt:   member.CreatedAt.String(),
+		UpdatedAt:   member.UpdatedAt.String(),
+	}
+}
+
+func WorkspaceMembersToResponses(members []entity.WorkspaceMember) []*model.WorkspaceMemberResponse {
+	memberResponses := make([]*model.WorkspaceMemberResponse, len(members))
+	for i := range members {
+		memberResponses[i] = WorkspaceMemberToResponse(&members[i])
+	}
+	return memberResponses
+}
+
+func WorkspaceInviteToResponse(invite *entity.WorkspaceInvite, token string) *model.WorkspaceInviteResponse {
+	return &model.WorkspaceInviteResponse{
+		ID:          invite.ID,
+		WorkspaceID: invite.WorkspaceID,
+		Email:       invite.Email,
+		Role:        invite.Role,
+		Token:       token,
+		ExpiresAt:   invite.ExpiresAt.String(),
+	}
+}
@@ -11,11 +11,14 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// PageMetadata locates a page, by number or by cursors. The totals are left out when they were not counted.
 type PageMetadata struct {
-	Page       int `json:"page"`
-	Size       int `json:"size"`
-	TotalItems int `json:"total_items"`
-	TotalPages int `json:"total_pages"`
+	Page       int     `json:"page,omitempty"`
+	Size       int     `json:"size"`
+	TotalItems *int    `json:"total_items,omitempty"`
+	TotalPages *int    `json:"total_pages,omitempty"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+	PrevCursor *string `json:"prev_cursor,omitempty"`
 }
 
 func NewResponse[T any](data T, paging *PageMetadata) *Response[T] {
@@ -0,0 +1,45 @@
+package model
+
+const (
+	ProjectDeleteModeInbox   = "inbox"
+	ProjectDeleteModeCascade = "cascade"
+)
+
+type ProjectResponse struct {
+	ID        string  `json:"id"`
+	UserID    *string `json:"user_id,omitempty"`
+	Name      string  `json:"name"`
+	Color     *string `json:"color,omitempty"`
+	Archived  bool    `json:"archived"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+type ProjectCreateRequest struct {
+	Name  string  `json:"name" validate:"required,gte=1,lte=100"`
+	Color *string `json:"color,omitempty" validate:"omitempty,hexcolor,len=7"`
+}
+
+type ProjectUpdateIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type ProjectUpdateRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,gte=1,lte=100"`
+	Color    *string `json:"color,omitempty" validate:"omitempty,hexcolor,len=7"`
+	Archived *bool   `json:"archived,omitempty" validate:"omitempty,boolean"`
+}
+
+type ProjectDeleteRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	// Mode decides what happens to the project's todos, inbox keeps them without a project
+	Mode string `query:"mode" validate:"omitempty,oneof=inbox cascade"`
+}
+
+type ProjectGetRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type ProjectGetAllRequest struct {
+	Archived *bool `query:"archived"`
+}
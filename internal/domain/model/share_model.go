@@ -0,0 +1,60 @@
+package model
+
+const (
+	ShareResourceTodo    = "todo"
+	ShareResourceProject = "project"
+)
+
+const (
+	ShareRoleViewer = "viewer"
+	ShareRoleEditor = "editor"
+	ShareRoleOwner  = "owner"
+)
+
+// ShareRoles are ordered from least to most access, each role includes the ones before it
+var ShareRoles = []string{ShareRoleViewer, ShareRoleEditor, ShareRoleOwner}
+
+// ShareRoleIncludes reports whether role grants at least the access of required
+func ShareRoleIncludes(role, required string) bool {
+	return shareRoleRank(role) >= shareRoleRank(required) && shareRoleRank(role) > 0
+}
+
+func shareRoleRank(role string) int {
+	for i, r := range ShareRoles {
+		if r == role {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+type ShareResponse struct {
+	ID           string `json:"id"`
+	ResourceType string `json:"resource_type"`
+	ResourceID   string `json:"resource_id"`
+	UserID       string `json:"user_id"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
+	GrantedBy    string `json:"granted_by"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type ShareCreateRequest struct {
+	// ResourceType is set by the route, it is not read from the request
+	ResourceType string `json:"-" validate:"required,oneof=todo project"`
+	ResourceID   string `param:"id" json:"-" validate:"required,uuid"`
+	Email        string `json:"email" validate:"required,email"`
+	Role         string `json:"role" validate:"required,oneof=viewer editor owner"`
+}
+
+type ShareGetAllRequest struct {
+	ResourceType string `validate:"required,oneof=todo project"`
+	ResourceID   string `param:"id" validate:"required,uuid"`
+}
+
+type ShareDeleteRequest struct {
+	ResourceType string `validate:"required,oneof=todo project"`
+	ResourceID   string `param:"id" validate:"required,uuid"`
+	ID           string `param:"share_id" validate:"required,uuid"`
+}
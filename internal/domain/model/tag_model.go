@@ -0,0 +1,28 @@
+package model
+
+type TagResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type TagCreateRequest struct {
+	Name string `json:"name" validate:"required,gte=1,lte=50"`
+}
+
+type TagUpdateIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type TagUpdateRequest struct {
+	Name string `json:"name" validate:"required,gte=1,lte=50"`
+}
+
+type TagDeleteRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type TagGetRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
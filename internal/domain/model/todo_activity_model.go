@@ -0,0 +1,59 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	TodoActivityCreated   = "created"
+	TodoActivityUpdated   = "updated"
+	TodoActivityCompleted = "completed"
+	TodoActivityDeleted   = "deleted"
+	TodoActivityRestored  = "restored"
+	TodoActivityReverted  = "reverted"
+)
+
+// TodoSnapshot is the state of a todo kept with each activity entry, field names are the ones changes refer to
+type TodoSnapshot struct {
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Done        bool       `json:"done"`
+	Priority    string     `json:"priority"`
+	DueAt       *time.Time `json:"due_at"`
+	StartAt     *time.Time `json:"start_at"`
+	ParentID    *string    `json:"parent_id"`
+	ProjectID   *string    `json:"project_id"`
+	Recurrence  *string    `json:"recurrence"`
+	Timezone    *string    `json:"timezone"`
+	Tags        []string   `json:"tags"`
+}
+
+// TodoActivityChange is a field that changed, the values are JSON as they appear in the snapshot
+type TodoActivityChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before" swaggertype:"object"`
+	After  json.RawMessage `json:"after" swaggertype:"object"`
+}
+
+type TodoActivityResponse struct {
+	ID      string `json:"id"`
+	TodoID  string `json:"todo_id"`
+	ActorID string `json:"actor_id"`
+	Action  string `json:"action"`
+	// Changes is empty for deletes and restores, which do not change any field
+	Changes   []*TodoActivityChange `json:"changes"`
+	Snapshot  *TodoSnapshot         `json:"snapshot"`
+	CreatedAt string                `json:"created_at"`
+}
+
+type TodoActivityGetAllRequest struct {
+	TodoID string `param:"id" validate:"required,uuid"`
+	Page   int    `query:"page" validate:"numeric"`
+	Size   int    `query:"size" validate:"numeric,lte=100"`
+}
+
+type TodoRevertRequest struct {
+	TodoID     string `param:"id" validate:"required,uuid"`
+	ActivityID string `param:"activity_id" validate:"required,uuid"`
+}
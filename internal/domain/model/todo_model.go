@@ -1,16 +1,101 @@
 package model
 
+import (
+	"github.com/savioruz/mikti-task/internal/platform/filter"
+	"time"
+)
+
+const (
+	TodoDueOverdue = "overdue"
+	TodoDueToday   = "today"
+)
+
+const (
+	TodoPriorityNone   = "none"
+	TodoPriorityLow    = "low"
+	TodoPriorityMedium = "medium"
+	TodoPriorityHigh   = "high"
+	TodoPriorityUrgent = "urgent"
+)
+
+// TodoProjectInbox selects todos that are not in any project
+const TodoProjectInbox = "inbox"
+
+const (
+	TodoTagModeAny = "any"
+	TodoTagModeAll = "all"
+)
+
+const (
+	TodoBulkOpCreate   = "create"
+	TodoBulkOpUpdate   = "update"
+	TodoBulkOpComplete = "complete"
+	TodoBulkOpDelete   = "delete"
+	TodoBulkOpMove     = "move"
+)
+
+// TodoPriorities lists priority levels from lowest to highest, the index is the stored level
+var TodoPriorities = []string{
+	TodoPriorityNone,
+	TodoPriorityLow,
+	TodoPriorityMedium,
+	TodoPriorityHigh,
+	TodoPriorityUrgent,
+}
+
 type TodoResponse struct {
-	ID        string  `json:"id"`
-	UserID    *string `json:"user_id,omitempty"`
-	Title     string  `json:"title"`
-	Done      bool    `json:"done"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	ID     string  `json:"id"`
+	UserID *string `json:"user_id,omitempty"`
+	Title  string  `json:"title"`
+	// Description is Markdown, DescriptionHTML is its sanitized rendering
+	Description     *string    `json:"description,omitempty"`
+	DescriptionHTML *string    `json:"description_html,omitempty"`
+	Done            bool       `json:"done"`
+	Priority        string     `json:"priority"`
+	DueAt           *time.Time `json:"due_at,omitempty"`
+	StartAt         *time.Time `json:"start_at,omitempty"`
+	ParentID        *string    `json:"parent_id,omitempty"`
+	ProjectID       *string    `json:"project_id,omitempty"`
+	// Recurrence and Timezone are only set on the open occurrence of a repeating todo
+	Recurrence *string       `json:"recurrence,omitempty"`
+	Timezone   *string       `json:"timezone,omitempty"`
+	SeriesID   *string       `json:"series_id,omitempty"`
+	Progress   *TodoProgress `json:"progress,omitempty"`
+	// Highlight wraps the words matching a search in <mark> tags
+	Highlight *string `json:"highlight,omitempty"`
+	// DescriptionHighlight is a snippet of the description with the words matching a search in <mark> tags
+	DescriptionHighlight *string        `json:"description_highlight,omitempty"`
+	Tags                 []*TagResponse `json:"tags"`
+	// Position is the place of the todo in its list when sorted by hand
+	Position float64 `json:"position"`
+	// Version goes up with every change, it is also sent as the ETag
+	Version   int64  `json:"version"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	// DeletedAt is only set on todos in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type TodoProgress struct {
+	Total   int `json:"total"`
+	Done    int `json:"done"`
+	Percent int `json:"percent"`
 }
 
 type TodoCreateRequest struct {
 	Title string `json:"title" validate:"required,gte=5,lte=255"`
+	// Description is Markdown
+	Description *string    `json:"description,omitempty" validate:"omitempty,lte=20000"`
+	Priority    *string    `json:"priority,omitempty" validate:"omitempty,oneof=none low medium high urgent"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	StartAt     *time.Time `json:"start_at,omitempty"`
+	ParentID    *string    `json:"parent_id,omitempty" validate:"omitempty,uuid"`
+	ProjectID   *string    `json:"project_id,omitempty" validate:"omitempty,uuid"`
+	Tags        []string   `json:"tags,omitempty" validate:"omitempty,dive,gte=1,lte=50,excludesall=0x2C"`
+	// Recurrence is an RRULE such as FREQ=WEEKLY;BYDAY=MO, the series starts at due_at
+	Recurrence *string `json:"recurrence,omitempty" validate:"omitempty,lte=255"`
+	// Timezone is the IANA zone occurrences keep their wall clock time in, defaults to UTC
+	Timezone *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
 }
 
 type TodoUpdateIDRequest struct {
@@ -19,38 +104,195 @@ type TodoUpdateIDRequest struct {
 
 type TodoUpdateRequest struct {
 	Title *string `json:"title,omitempty" validate:"omitempty,gte=5,lte=255"`
-	Done  *bool   `json:"done,omitempty" validate:"omitempty,boolean"`
+	// Description replaces the Markdown description, an empty one clears it
+	Description *string    `json:"description,omitempty" validate:"omitempty,lte=20000"`
+	Done        *bool      `json:"done,omitempty" validate:"omitempty,boolean"`
+	Priority    *string    `json:"priority,omitempty" validate:"omitempty,oneof=none low medium high urgent"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	StartAt     *time.Time `json:"start_at,omitempty"`
+	ParentID    *string    `json:"parent_id,omitempty" validate:"omitempty,uuid"`
+	ProjectID   *string    `json:"project_id,omitempty" validate:"omitempty,uuid"`
+	// Cascade applies a change of done to every subtask as well
+	Cascade *bool `json:"cascade,omitempty"`
+	// Tags replaces the todo's tags when set, an empty list clears them
+	Tags []string `json:"tags,omitempty" validate:"omitempty,dive,gte=1,lte=50,excludesall=0x2C"`
+	// Recurrence replaces the rule of a repeating todo, the series restarts at due_at
+	Recurrence *string `json:"recurrence,omitempty" validate:"omitempty,lte=255"`
+	Timezone   *string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	// ExpectedVersion makes the update fail unless the todo is still at this version, it comes from If-Match
+	ExpectedVersion *int64 `json:"-"`
+}
+
+const (
+	// TodoPatchMerge and TodoPatchJSON are the media types PATCH accepts, RFC 7396 and RFC 6902
+	TodoPatchMerge = "application/merge-patch+json"
+	TodoPatchJSON  = "application/json-patch+json"
+)
+
+type TodoPatchRequest struct {
+	ID string `validate:"required,uuid"`
+	// Format is the media type of Patch
+	Format string `validate:"required,oneof=application/merge-patch+json application/json-patch+json"`
+	Patch  []byte `validate:"required"`
+	// ExpectedVersion makes the patch fail unless the todo is still at this version, it comes from If-Match
+	ExpectedVersion *int64
+}
+
+// TodoPatchDocument is what a patch applies to, a field that is missing or null afterwards is cleared
+type TodoPatchDocument struct {
+	Title       string     `json:"title" validate:"required,gte=5,lte=255"`
+	Description *string    `json:"description" validate:"omitempty,lte=20000"`
+	Done        bool       `json:"done"`
+	Priority    string     `json:"priority" validate:"required,oneof=none low medium high urgent"`
+	DueAt       *time.Time `json:"due_at"`
+	StartAt     *time.Time `json:"start_at"`
+	ParentID    *string    `json:"parent_id" validate:"omitempty,uuid"`
+	ProjectID   *string    `json:"project_id" validate:"omitempty,uuid"`
+	Recurrence  *string    `json:"recurrence" validate:"omitempty,lte=255"`
+	Timezone    *string    `json:"timezone" validate:"omitempty,timezone"`
+	Tags        []string   `json:"tags" validate:"omitempty,dive,gte=1,lte=50,excludesall=0x2C"`
+}
+
+// TodoReorderRequest places a todo in its list right after After, right before Before, or between the two
+type TodoReorderRequest struct {
+	ID     string  `param:"id" validate:"required,uuid"`
+	After  *string `json:"after,omitempty" validate:"required_without=Before,omitempty,uuid"`
+	Before *string `json:"before,omitempty" validate:"omitempty,uuid"`
 }
 
 type TodoDeleteRequest struct {
 	ID string `param:"id" validate:"required,uuid"`
+	// Cascade deletes every subtask, otherwise they move up to the deleted todo's parent
+	Cascade bool `query:"cascade"`
+	// Permanent skips the trash, it also purges a todo that is already in the trash
+	Permanent bool `query:"permanent"`
 }
 
 type TodoGetRequest struct {
 	ID string `param:"id" validate:"required,uuid"`
 }
 
+type TodoBulkRequest struct {
+	// Atomic discards every operation when one of them fails, otherwise only the failed ones are skipped
+	Atomic     bool                `json:"atomic"`
+	Operations []TodoBulkOperation `json:"operations" validate:"required,min=1,max=100"`
+}
+
+type TodoBulkOperation struct {
+	Op string `json:"op" validate:"required,oneof=create update complete delete move"`
+	// ID is the target todo, required by every operation except create
+	ID     *string            `json:"id,omitempty" validate:"omitempty,uuid"`
+	Create *TodoCreateRequest `json:"create,omitempty"`
+	Update *TodoUpdateRequest `json:"update,omitempty"`
+	// ParentID or ProjectID is where a move puts the todo, project_id also accepts inbox
+	ParentID  *string `json:"parent_id,omitempty" validate:"omitempty,uuid"`
+	ProjectID *string `json:"project_id,omitempty" validate:"omitempty,uuid|eq=inbox"`
+	// Cascade applies complete and delete to subtasks as well
+	Cascade bool `json:"cascade,omitempty"`
+}
+
+type TodoBulkResult struct {
+	Index  int           `json:"index"`
+	Op     string        `json:"op"`
+	Status int           `json:"status"`
+	Error  *string       `json:"error,omitempty"`
+	Data   *TodoResponse `json:"data,omitempty"`
+}
+
+type TodoBulkResponse struct {
+	Atomic    bool              `json:"atomic"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []*TodoBulkResult `json:"results"`
+}
+
+type TodoTrashRequest struct {
+	Page int `query:"page" validate:"numeric"`
+	Size int `query:"size" validate:"numeric"`
+}
+
 type TodoSearchRequest struct {
-	Title string  `query:"title" validate:"omitempty,gte=2,lte=255"`
-	Page  int     `query:"page" validate:"numeric"`
-	Size  int     `query:"size" validate:"numeric"`
-	Sort  *string `query:"sort" validate:"omitempty,oneof='id' title done created_at updated_at"`
-	Order *string `query:"order" validate:"omitempty,oneof=asc desc"`
+	// Q is a full-text query, supporting "phrases", prefix* matches, -exclusions and OR
+	Q string `query:"q" validate:"omitempty,lte=255"`
+	// Title is the former name of q, kept for older clients
+	Title     string   `query:"title" validate:"omitempty,gte=2,lte=255"`
+	Page      int      `query:"page" validate:"numeric"`
+	Size      int      `query:"size" validate:"numeric"`
+	Sort      *string  `query:"sort" validate:"omitempty,oneof='id' title done created_at updated_at rank"`
+	Order     *string  `query:"order" validate:"omitempty,oneof=asc desc"`
+	Tags      []string `query:"tags" validate:"omitempty,dive,lte=255"`
+	TagMode   *string  `query:"tag_mode" validate:"omitempty,oneof=any all"`
+	ProjectID *string  `query:"project_id" validate:"omitempty,uuid|eq=inbox"`
+	// Filter is an expression such as done:false AND created_at>2026-01-01 AND title~"report"
+	Filter string `query:"filter" validate:"omitempty,lte=1024"`
+	// After and Before are cursors from a previous page, they take the place of the page number
+	After  *string `query:"after" validate:"omitempty,excluded_with=Before"`
+	Before *string `query:"before"`
+	// Total counts the matching todos, by default only when paging by number
+	Total *bool `query:"total"`
 }
 
 type TodoGetAllRequest struct {
-	Page  int     `query:"page" validate:"numeric"`
-	Size  int     `query:"size" validate:"numeric"`
-	Sort  *string `query:"sort" validate:"omitempty,oneof=id title done created_at updated_at"`
-	Order *string `query:"order" validate:"omitempty,oneof=asc desc"`
+	Page      int        `query:"page" validate:"numeric"`
+	Size      int        `query:"size" validate:"numeric"`
+	Sort      *string    `query:"sort" validate:"omitempty,oneof=id title done priority due_at created_at updated_at position"`
+	Order     *string    `query:"order" validate:"omitempty,oneof=asc desc"`
+	Priority  *string    `query:"priority" validate:"omitempty,oneof=none low medium high urgent"`
+	Tags      []string   `query:"tags" validate:"omitempty,dive,lte=255"`
+	TagMode   *string    `query:"tag_mode" validate:"omitempty,oneof=any all"`
+	ProjectID *string    `query:"project_id" validate:"omitempty,uuid|eq=inbox"`
+	Due       *string    `query:"due" validate:"omitempty,oneof=overdue today"`
+	DueBefore *time.Time `query:"due_before"`
+	DueAfter  *time.Time `query:"due_after"`
+	// Filter is an expression such as done:false AND created_at>2026-01-01 AND title~"report"
+	Filter string `query:"filter" validate:"omitempty,lte=1024"`
+	// After and Before are cursors from a previous page, they take the place of the page number
+	After  *string `query:"after" validate:"omitempty,excluded_with=Before"`
+	Before *string `query:"before"`
+	// Total counts the matching todos, by default only when paging by number
+	Total *bool `query:"total"`
 }
 
 type TodoQueryOptions struct {
-	UserID  *string
-	Title   *string
-	Page    int
-	Size    int
-	Sort    string
-	Order   string
-	IsAdmin bool
+	UserID *string
+	// Query is a to_tsquery expression matched against the search vector
+	Query     *string
+	Priority  *int
+	Tags      []string
+	TagMode   string
+	ProjectID *string
+	Due       string
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	Page      int
+	Size      int
+	// Filter is a parsed filter expression, compiled against the filterable columns
+	Filter filter.Node
+	// After and Before are cursors, when one is set the page number is ignored
+	After     *string
+	Before    *string
+	WithTotal bool
+	Sort      string
+	Order     string
+	IsAdmin   bool
+	// WorkspaceID is the active workspace, the repository keeps every query inside it
+	WorkspaceID string
+	// WorkspaceWide lists every todo of the workspace instead of the user's own and shared ones
+	WorkspaceWide bool
+}
+
+// TodoPage describes where a page of todos sits in the whole list
+type TodoPage struct {
+	// TotalItems is only counted when asked for
+	TotalItems *int64
+	NextCursor *string
+	PrevCursor *string
+}
+
+type TodoTrashOptions struct {
+	UserID       *string
+	DeletedAfter *time.Time
+	Page         int
+	Size         int
+	IsAdmin      bool
 }
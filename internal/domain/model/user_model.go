@@ -1,12 +1,16 @@
 package model
 
+import "time"
+
 type UserResponse struct {
-	ID        string `json:"id"`
-	Email     string `json:"email"`
-	Role      string `json:"role"`
-	Status    bool   `json:"status"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID     string `json:"id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+	Status bool   `json:"status"`
+	// EmailVerifiedAt is missing until the user verified their email
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       string     `json:"created_at"`
+	UpdatedAt       string     `json:"updated_at"`
 }
 
 type RegisterRequest struct {
@@ -14,9 +18,21 @@ type RegisterRequest struct {
 	Password string `json:"password" validate:"required,gte=8,lte=255"`
 }
 
+type VerifyEmailRequest struct {
+	Token string `query:"token" validate:"required,jwt"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email,lte=100"`
+}
+
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email,lte=100"`
 	Password string `json:"password" validate:"required,gte=8,lte=255"`
+	// WorkspaceID picks the default workspace of the tokens, the personal workspace is used without it
+	WorkspaceID *string `json:"workspace_id,omitempty" validate:"omitempty,uuid"`
+	// UserAgent labels the session in the list of sessions
+	UserAgent string `json:"-" validate:"lte=255"`
 }
 
 type TokenResponse struct {
@@ -27,3 +43,22 @@ type TokenResponse struct {
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required,jwt"`
 }
+
+type SessionResponse struct {
+	ID        string  `json:"id"`
+	UserAgent *string `json:"user_agent"`
+	// Current marks the session of the calling token
+	Current    bool   `json:"current"`
+	LastUsedAt string `json:"last_used_at"`
+	ExpiresAt  string `json:"expires_at"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type SessionDeleteRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type SessionRevokeRequest struct {
+	// UserID is the user whose sessions are revoked, the calling user without it
+	UserID string `param:"id" validate:"omitempty,uuid"`
+}
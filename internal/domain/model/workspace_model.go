@@ -0,0 +1,89 @@
+package model
+
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+	WorkspaceRoleGuest  = "guest"
+)
+
+// WorkspaceShareRole is the access a workspace role gives to every todo and project in the workspace,
+// guests get none and only see what is shared with them
+func WorkspaceShareRole(role string) string {
+	switch role {
+	case WorkspaceRoleOwner, WorkspaceRoleAdmin:
+		return ShareRoleOwner
+	case WorkspaceRoleMember:
+		return ShareRoleEditor
+	default:
+		return ""
+	}
+}
+
+// WorkspaceRoleManages reports whether role may invite, update and remove members
+func WorkspaceRoleManages(role string) bool {
+	return role == WorkspaceRoleOwner || role == WorkspaceRoleAdmin
+}
+
+type WorkspaceResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Personal bool   `json:"personal"`
+	// Role is the calling user's role in the workspace
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type WorkspaceCreateRequest struct {
+	Name string `json:"name" validate:"required,gte=1,lte=100"`
+}
+
+type WorkspaceGetRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type WorkspaceUpdateRequest struct {
+	ID   string `param:"id" json:"-" validate:"required,uuid"`
+	Name string `json:"name" validate:"required,gte=1,lte=100"`
+}
+
+type WorkspaceMemberResponse struct {
+	WorkspaceID string `json:"workspace_id"`
+	UserID      string `json:"user_id"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type WorkspaceMemberUpdateRequest struct {
+	ID     string `param:"id" json:"-" validate:"required,uuid"`
+	UserID string `param:"user_id" json:"-" validate:"required,uuid"`
+	Role   string `json:"role" validate:"required,oneof=admin member guest"`
+}
+
+type WorkspaceMemberDeleteRequest struct {
+	ID     string `param:"id" validate:"required,uuid"`
+	UserID string `param:"user_id" validate:"required,uuid"`
+}
+
+type WorkspaceInviteRequest struct {
+	ID    string `param:"id" json:"-" validate:"required,uuid"`
+	Email string `json:"email" validate:"required,email,lte=100"`
+	Role  string `json:"role" validate:"required,oneof=admin member guest"`
+}
+
+type WorkspaceInviteResponse struct {
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspace_id"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	// Token is only returned when the invite is created, it is what the invited user accepts
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type WorkspaceInviteAcceptRequest struct {
+	Token string `json:"token" validate:"required,hexadecimal,len=64"`
+}
@@ -0,0 +1,38 @@
+// Package cursor encodes the position of a row in a sorted list as an opaque token.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("cursor: invalid cursor")
+
+// Cursor points at a row by the values of its sort keys, the last of them being the unique id.
+// Sort names the ordering the cursor was taken from, it is only valid for that ordering.
+type Cursor struct {
+	Sort   string    `json:"s"`
+	Values []*string `json:"v"`
+}
+
+// Encode returns the cursor as a URL safe token
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode reads a token made by Encode
+func Decode(token string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Sort == "" || len(c.Values) == 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,48 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	value := "2026-01-02T03:04:05Z"
+	c := Cursor{Sort: "due_at:asc", Values: []*string{&value, nil}}
+
+	got, err := Decode(c.Encode())
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(*got, c) {
+		t.Errorf("Decode() = %+v, want %+v", *got, c)
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"not base64", "not a cursor!"},
+		{"not json", encode("id:asc")},
+		{"truncated json", encode(`{"s":"id:asc","v":["a"`)},
+		{"no sort", encode(`{"v":["a"]}`)},
+		{"no values", encode(`{"s":"id:asc","v":[]}`)},
+		{"wrong value type", encode(`{"s":"id:asc","v":[1]}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.token); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Decode(%q) error = %v, want ErrInvalidCursor", tt.token, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package filter
+
+import (
+	"strconv"
+)
+
+// Operator compares a field to a value
+type Operator string
+
+const (
+	OpEqual        Operator = ":"
+	OpNotEqual     Operator = "!:"
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+	OpContains     Operator = "~"
+	OpNotContains  Operator = "!~"
+)
+
+// operators are matched longest first
+var operators = []Operator{OpNotEqual, OpNotContains, OpGreaterEqual, OpLessEqual, OpEqual, OpGreater, OpLess, OpContains}
+
+// Node is an element of a parsed filter expression
+type Node interface {
+	String() string
+}
+
+type And struct {
+	Left, Right Node
+}
+
+type Or struct {
+	Left, Right Node
+}
+
+type Not struct {
+	Expr Node
+}
+
+// Comparison is a single field condition, Pos is the column it starts at
+type Comparison struct {
+	Field string
+	Op    Operator
+	Value Value
+	Pos   int
+}
+
+// Value is the right hand side of a comparison, a bare null means no value
+type Value struct {
+	Text   string
+	Quoted bool
+}
+
+func (v Value) IsNull() bool {
+	return !v.Quoted && v.Text == "null"
+}
+
+func (n *And) String() string {
+	return "(" + n.Left.String() + " AND " + n.Right.String() + ")"
+}
+
+func (n *Or) String() string {
+	return "(" + n.Left.String() + " OR " + n.Right.String() + ")"
+}
+
+func (n *Not) String() string {
+	return "NOT " + n.Expr.String()
+}
+
+func (n *Comparison) String() string {
+	return n.Field + string(n.Op) + n.Value.String()
+}
+
+func (v Value) String() string {
+	if v.Quoted {
+		return strconv.Quote(v.Text)
+	}
+	return v.Text
+}
+
+// Errorf reports a problem with the comparison, such as a field or value that cannot be filtered on
+func (n *Comparison) Errorf(format string, args ...interface{}) error {
+	return errorf(n.Pos, format, args...)
+}
@@ -0,0 +1,26 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Error is a malformed or unsupported filter expression, Pos is the column the problem was found at
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter: %s at column %d", e.Msg, e.Pos)
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrInvalidFilter
+}
+
+func errorf(pos int, format string, args ...interface{}) error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,215 @@
+// Package filter parses list filter expressions such as
+//
+//	done:false AND created_at>2026-01-01 AND (title~"report" OR NOT priority<high)
+//
+// into an AST. The package knows nothing about fields, callers check them against their own whitelist.
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxLength bounds the input and MaxDepth the nesting, so a request cannot make the parser work hard
+	MaxLength = 1024
+	MaxDepth  = 32
+)
+
+type parser struct {
+	input []rune
+	pos   int
+	depth int
+}
+
+// Parse reads an expression. Comparisons are field, operator and value without spaces around the
+// operator, joined with AND, OR, NOT and parentheses. AND binds tighter than OR. Values are bare words
+// or "quoted strings" with \" and \\ escapes, a bare null stands for no value.
+func Parse(input string) (Node, error) {
+	if len(input) > MaxLength {
+		return nil, errorf(MaxLength, "expression is longer than %d characters", MaxLength)
+	}
+
+	p := &parser{input: []rune(input)}
+	p.skipSpace()
+	if p.eof() {
+		return nil, errorf(1, "expression is empty")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.input[p.pos])
+	}
+
+	return node, nil
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("AND") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > MaxDepth {
+		return nil, p.errorf("expression is nested deeper than %d levels", MaxDepth)
+	}
+
+	if p.keyword("NOT") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr}, nil
+	}
+
+	p.skipSpace()
+	if !p.eof() && p.input[p.pos] == '(' {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.eof() || p.input[p.pos] != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	start := p.pos
+	for !p.eof() && (p.input[p.pos] == '_' || unicode.IsLetter(p.input[p.pos]) || (p.pos > start && unicode.IsDigit(p.input[p.pos]))) {
+		p.pos++
+	}
+	if p.pos == start {
+		if p.eof() {
+			return nil, p.errorf("expected a field name")
+		}
+		return nil, p.errorf("expected a field name, found %q", p.input[p.pos])
+	}
+	comparison := &Comparison{Field: strings.ToLower(string(p.input[start:p.pos])), Pos: start + 1}
+
+	rest := string(p.input[p.pos:])
+	for _, op := range operators {
+		if strings.HasPrefix(rest, string(op)) {
+			comparison.Op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if comparison.Op == "" {
+		return nil, p.errorf("expected an operator after %q, one of : !: > >= < <= ~ !~", comparison.Field)
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	comparison.Value = value
+
+	return comparison, nil
+}
+
+func (p *parser) parseValue() (Value, error) {
+	if p.eof() || unicode.IsSpace(p.input[p.pos]) {
+		return Value{}, p.errorf("expected a value")
+	}
+
+	if p.input[p.pos] != '"' {
+		start := p.pos
+		for !p.eof() && !unicode.IsSpace(p.input[p.pos]) && p.input[p.pos] != '(' && p.input[p.pos] != ')' && p.input[p.pos] != '"' {
+			p.pos++
+		}
+		if p.pos == start {
+			return Value{}, p.errorf("expected a value")
+		}
+		return Value{Text: string(p.input[start:p.pos])}, nil
+	}
+
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		r := p.input[p.pos]
+		p.pos++
+		switch {
+		case r == '"':
+			return Value{Text: b.String(), Quoted: true}, nil
+		case r == '\\' && !p.eof() && (p.input[p.pos] == '"' || p.input[p.pos] == '\\'):
+			b.WriteRune(p.input[p.pos])
+			p.pos++
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return Value{}, errorf(start+1, "unterminated string")
+}
+
+// keyword consumes a case-insensitive keyword that stands on its own
+func (p *parser) keyword(word string) bool {
+	p.skipSpace()
+	end := p.pos + len(word)
+	if end > len(p.input) || !strings.EqualFold(string(p.input[p.pos:end]), word) {
+		return false
+	}
+	if end < len(p.input) && !unicode.IsSpace(p.input[end]) && p.input[end] != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return errorf(p.pos+1, format, args...)
+}
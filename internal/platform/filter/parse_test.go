@@ -0,0 +1,75 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"comparison", "done:false", "done:false"},
+		{"and", `done:false AND created_at>2026-01-01 AND title~"report"`, `((done:false AND created_at>2026-01-01) AND title~"report")`},
+		{"and binds tighter than or", "done:true OR priority>=high AND tag:work", "(done:true OR (priority>=high AND tag:work))"},
+		{"parentheses", "(done:true OR priority>=high) AND tag:work", "((done:true OR priority>=high) AND tag:work)"},
+		{"not", "NOT due_at:null", "NOT due_at:null"},
+		{"not before parenthesis", "not(done:true)", "NOT done:true"},
+		{"lower case keywords", "done:true and tag:a or tag:b", "((done:true AND tag:a) OR tag:b)"},
+		{"field names are case insensitive", "Done:true", "done:true"},
+		{"timestamp value", "created_at<=2026-01-01T10:00:00Z", "created_at<=2026-01-01T10:00:00Z"},
+		{"escapes", `title:"say \"hi\" \\ bye"`, `title:"say \"hi\" \\ bye"`},
+		{"quoted keyword", `title:"AND"`, `title:"AND"`},
+		{"two character operators", "title!~x AND id!:y", "(title!~x AND id!:y)"},
+		{"surrounding space", "  done:true  ", "done:true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if got := node.String(); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		pos   int
+	}{
+		{"empty", "   ", 1},
+		{"missing operator", "done", 5},
+		{"missing value", "done: AND x:y", 6},
+		{"space before operator", "done :true", 5},
+		{"dangling and", "done:true AND", 14},
+		{"unbalanced parenthesis", "(done:true", 11},
+		{"stray parenthesis", "done:true)", 10},
+		{"unterminated string", `title:"abc`, 7},
+		{"not a field", "1done:true", 1},
+		{"missing keyword", "done:true tag:a", 11},
+		{"too deep", strings.Repeat("(", MaxDepth+1) + "done:true" + strings.Repeat(")", MaxDepth+1), MaxDepth + 1},
+		{"too long", "title~" + strings.Repeat("a", MaxLength), MaxLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if !errors.Is(err, ErrInvalidFilter) {
+				t.Fatalf("Parse(%q) error = %v, want ErrInvalidFilter", tt.input, err)
+			}
+			var filterErr *Error
+			if !errors.As(err, &filterErr) || filterErr.Pos != tt.pos {
+				t.Errorf("Parse(%q) error = %v, want column %d", tt.input, err, tt.pos)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package helper
 import (
 	"context"
 	"errors"
+	"github.com/savioruz/mikti-task/internal/domain/model"
 	"github.com/savioruz/mikti-task/internal/platform/jwt"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
 )
 
 func (h *ContextHelper) GetJWTClaims(ctx context.Context) (*jwt.JWTClaims, error) {
@@ -27,6 +29,36 @@ func (h *ContextHelper) VerifyOwnership(ctx context.Context, resourceOwnerID str
 	return errors.New("forbidden: user does not have permission to access this resource")
 }
 
+// VerifyAccess extends VerifyOwnership with workspace roles and shares, the owner and admins always pass while
+// anyone else needs a workspace role or a grant of at least the required role. grant looks up the role shared
+// with the calling user.
+func (h *ContextHelper) VerifyAccess(ctx context.Context, resourceOwnerID, required string, grant func(userID string) (string, error)) error {
+	if err := h.VerifyOwnership(ctx, resourceOwnerID); err == nil {
+		return nil
+	}
+
+	// The resource was loaded inside the active workspace, so the caller's role there applies to it
+	if workspace, ok := tenant.FromContext(ctx); ok && model.ShareRoleIncludes(model.WorkspaceShareRole(workspace.Role), required) {
+		return nil
+	}
+
+	claims, err := h.GetJWTClaims(ctx)
+	if err != nil {
+		return err
+	}
+
+	role, err := grant(claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !model.ShareRoleIncludes(role, required) {
+		return errors.New("forbidden: user does not have permission to access this resource")
+	}
+
+	return nil
+}
+
 func (h *ContextHelper) IsAdmin(ctx context.Context) bool {
 	claims, err := h.GetJWTClaims(ctx)
 	if err != nil {
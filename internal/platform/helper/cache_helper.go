@@ -3,23 +3,84 @@ package helper
 import (
 	"fmt"
 	"github.com/savioruz/mikti-task/internal/domain/model"
+	"strings"
+	"time"
 )
 
+// ListCachePattern matches every list BuildCacheKey caches for a workspace, so a write can drop them all at once
+func (h *ContextHelper) ListCachePattern(workspaceID string) string {
+	return listPrefix(workspaceID) + "*"
+}
+
+func listPrefix(workspaceID string) string {
+	return fmt.Sprintf("todos:list:%s:", workspaceID)
+}
+
+// BuildCacheKey is the key a list is cached under, an empty key means the list must not be cached
 func (h *ContextHelper) BuildCacheKey(opts model.TodoQueryOptions) string {
-	var cacheKey string
+	var scope string
+
+	switch {
+	case opts.IsAdmin && opts.UserID != nil:
+		scope = fmt.Sprintf("admin:user:%s", *opts.UserID)
+	case opts.IsAdmin:
+		scope = "admin:all"
+	case opts.WorkspaceWide:
+		// Every member of the workspace sees the same list, so they share one entry
+		scope = "workspace"
+	default:
+		scope = fmt.Sprintf("user:%s", *opts.UserID)
+	}
+
+	cacheKey := fmt.Sprintf("%s%s:page:%d:size:%dsort:%sorder:%s", listPrefix(opts.WorkspaceID), scope, opts.Page, opts.Size, opts.Sort, opts.Order)
+
+	if opts.Query != nil {
+		cacheKey = fmt.Sprintf("%s:q:%s", cacheKey, *opts.Query)
+	}
+
+	if opts.ProjectID != nil {
+		cacheKey = fmt.Sprintf("%s:project:%s", cacheKey, *opts.ProjectID)
+	}
+
+	if opts.Priority != nil {
+		cacheKey = fmt.Sprintf("%s:priority:%d", cacheKey, *opts.Priority)
+	}
+
+	if len(opts.Tags) > 0 {
+		cacheKey = fmt.Sprintf("%s:tags:%s:%s", cacheKey, opts.TagMode, strings.Join(opts.Tags, ","))
+	}
+
+	switch opts.Due {
+	case model.TodoDueOverdue:
+		// Todos turn overdue by the second, any cached list would be out of date right away
+		return ""
+	case model.TodoDueToday:
+		// The day is the server's, like the one the repository filters on, so the entry expires with it
+		cacheKey = fmt.Sprintf("%s:due:%s:%s", cacheKey, opts.Due, time.Now().Format("2006-01-02"))
+	}
+
+	if opts.DueBefore != nil {
+		cacheKey = fmt.Sprintf("%s:due_before:%d", cacheKey, opts.DueBefore.Unix())
+	}
+
+	if opts.DueAfter != nil {
+		cacheKey = fmt.Sprintf("%s:due_after:%d", cacheKey, opts.DueAfter.Unix())
+	}
+
+	if opts.Filter != nil {
+		cacheKey = fmt.Sprintf("%s:filter:%s", cacheKey, opts.Filter.String())
+	}
+
+	if opts.After != nil {
+		cacheKey = fmt.Sprintf("%s:after:%s", cacheKey, *opts.After)
+	}
 
-	if opts.IsAdmin {
-		if opts.UserID != nil {
-			cacheKey = fmt.Sprintf("todos:admin:user:%s:page:%d:size:%dsort:%sorder:%s", *opts.UserID, opts.Page, opts.Size, opts.Sort, opts.Order)
-		} else {
-			cacheKey = fmt.Sprintf("todos:admin:all:page:%d:size:%dsort:%sorder:%s", opts.Page, opts.Size, opts.Sort, opts.Order)
-		}
-	} else {
-		cacheKey = fmt.Sprintf("todos:user:%s:page:%d:size:%dsort:%sorder:%s", *opts.UserID, opts.Page, opts.Size, opts.Sort, opts.Order)
+	if opts.Before != nil {
+		cacheKey = fmt.Sprintf("%s:before:%s", cacheKey, *opts.Before)
 	}
 
-	if opts.Title != nil {
-		cacheKey = fmt.Sprintf("%s:title:%s", cacheKey, *opts.Title)
+	if opts.WithTotal {
+		cacheKey = fmt.Sprintf("%s:total", cacheKey)
 	}
 
 	return cacheKey
@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListCachePattern(t *testing.T) {
+	h := NewContextHelper()
+	userID := "user"
+	query, project, priority := "buy & milk", "project", 2
+	due := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	after := "cursor"
+
+	pattern := h.ListCachePattern("workspace")
+
+	for _, opts := range []model.TodoQueryOptions{
+		{UserID: &userID, Page: 1, Size: 10},
+		{UserID: &userID, Page: 2, Size: 20, Sort: "position", Order: "asc", WorkspaceWide: true},
+		{UserID: &userID, Query: &query, ProjectID: &project, Priority: &priority, Tags: []string{"a", "b"}, TagMode: model.TodoTagModeAll},
+		{UserID: &userID, Due: model.TodoDueToday, DueBefore: &due, DueAfter: &due, After: &after, WithTotal: true},
+		{IsAdmin: true, UserID: &userID, Page: 1, Size: 10},
+		{IsAdmin: true, Page: 1, Size: 10},
+	} {
+		opts.WorkspaceID = "workspace"
+		key := h.BuildCacheKey(opts)
+		if ok, err := path.Match(pattern, key); err != nil || !ok {
+			t.Errorf("pattern %q does not match key %q", pattern, key)
+		}
+
+		opts.WorkspaceID = "other"
+		key = h.BuildCacheKey(opts)
+		if ok, _ := path.Match(pattern, key); ok {
+			t.Errorf("pattern %q matches key %q of another workspace", pattern, key)
+		}
+	}
+}
+
+func TestBuildCacheKeyWorkspaceWide(t *testing.T) {
+	h := NewContextHelper()
+	userID, otherID := "user", "other"
+
+	wide := model.TodoQueryOptions{UserID: &userID, WorkspaceID: "workspace", WorkspaceWide: true, Page: 1, Size: 10}
+	otherWide := wide
+	otherWide.UserID = &otherID
+	if h.BuildCacheKey(wide) != h.BuildCacheKey(otherWide) {
+		t.Errorf("workspace-wide keys differ between members: %q and %q", h.BuildCacheKey(wide), h.BuildCacheKey(otherWide))
+	}
+
+	own := wide
+	own.WorkspaceWide = false
+	otherOwn := own
+	otherOwn.UserID = &otherID
+	if h.BuildCacheKey(own) == h.BuildCacheKey(otherOwn) {
+		t.Errorf("keys of own lists are shared between users: %q", h.BuildCacheKey(own))
+	}
+	if h.BuildCacheKey(own) == h.BuildCacheKey(wide) {
+		t.Errorf("own and workspace-wide lists share the key %q", h.BuildCacheKey(own))
+	}
+}
+
+func TestBuildCacheKeyRelativeDue(t *testing.T) {
+	h := NewContextHelper()
+	userID := "user"
+
+	opts := model.TodoQueryOptions{UserID: &userID, WorkspaceID: "workspace", Due: model.TodoDueOverdue}
+	if key := h.BuildCacheKey(opts); key != "" {
+		t.Errorf("BuildCacheKey(overdue) = %q, want no key", key)
+	}
+
+	opts.Due = model.TodoDueToday
+	today := time.Now().Format("2006-01-02")
+	if key := h.BuildCacheKey(opts); !strings.Contains(key, ":due:today:"+today) {
+		t.Errorf("BuildCacheKey(today) = %q, want the date %s in it", key, today)
+	}
+}
@@ -0,0 +1,28 @@
+package jsonpatch
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidPatch is a patch that is not well formed
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrUnapplicable is a well formed patch that does not fit the document, like a missing path or a failed test
+	ErrUnapplicable = errors.New("patch cannot be applied")
+)
+
+// Error is a problem with one operation of a JSON patch, Index is its position in the patch
+type Error struct {
+	Index int
+	Msg   string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: operation %d: %s", e.Err, e.Index, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,41 @@
+package jsonpatch
+
+import "fmt"
+
+// MergePatch applies a JSON merge patch (RFC 7396) to a document. Members set to null are removed,
+// objects are merged recursively and any other value replaces what was there.
+func MergePatch(document, patch []byte) ([]byte, error) {
+	doc, err := decode(document)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	return encode(merge(doc, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	members, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+
+	for name, value := range members {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = merge(object[name], value)
+	}
+
+	return object
+}
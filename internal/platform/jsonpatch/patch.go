@@ -0,0 +1,365 @@
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// operation is one step of a JSON patch, a Value of null is kept apart from a missing one
+type operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply applies a JSON patch (RFC 6902) to a document. The operations run in order and the patch is applied
+// as a whole or not at all.
+func Apply(document, patch []byte) ([]byte, error) {
+	doc, err := decode(document)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	var operations []operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	for i, op := range operations {
+		doc, err = apply(doc, op)
+		if err != nil {
+			var e *Error
+			if errors.As(err, &e) {
+				e.Index = i
+			}
+			return nil, err
+		}
+	}
+
+	return encode(doc)
+}
+
+func apply(doc interface{}, op operation) (interface{}, error) {
+	if op.Path == nil {
+		return nil, invalid("missing path")
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		value, err := operationValue(op)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "remove":
+		doc, _, err := remove(doc, path)
+		return doc, err
+	case "replace":
+		value, err := operationValue(op)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := get(doc, path); err != nil {
+			return nil, err
+		}
+		doc, _, err = remove(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "move":
+		from, err := operationFrom(op)
+		if err != nil {
+			return nil, err
+		}
+		if *op.From == *op.Path {
+			_, err := get(doc, path)
+			return doc, err
+		}
+		if strings.HasPrefix(*op.Path, *op.From+"/") {
+			return nil, invalid("cannot move a value into itself")
+		}
+		doc, value, err := remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "copy":
+		from, err := operationFrom(op)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		value, err = clone(value)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case "test":
+		value, err := operationValue(op)
+		if err != nil {
+			return nil, err
+		}
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(current, value) {
+			return nil, unapplicable(fmt.Sprintf("test of %q failed", *op.Path))
+		}
+		return doc, nil
+	default:
+		return nil, invalid(fmt.Sprintf("unknown op %q", op.Op))
+	}
+}
+
+func operationValue(op operation) (interface{}, error) {
+	if len(op.Value) == 0 {
+		return nil, invalid("missing value")
+	}
+	return decode(op.Value)
+}
+
+func operationFrom(op operation) ([]string, error) {
+	if op.From == nil {
+		return nil, invalid("missing from")
+	}
+	return parsePointer(*op.From)
+}
+
+// parsePointer splits a JSON pointer (RFC 6901) into its unescaped reference tokens, the empty pointer is the whole document
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, invalid(fmt.Sprintf("path %q does not start with /", pointer))
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	node := doc
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[token]
+			if !ok {
+				return nil, unapplicable(fmt.Sprintf("%q does not exist", token))
+			}
+			node = child
+		case []interface{}:
+			i, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, unapplicable(fmt.Sprintf("%q does not exist", token))
+		}
+	}
+	return node, nil
+}
+
+// add sets a member of an object or inserts into an array, - appends to an array
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			p[token] = value
+			return p, nil
+		case []interface{}:
+			if token == "-" {
+				return append(p, value), nil
+			}
+			i, err := arrayIndex(token, len(p))
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		default:
+			return nil, unapplicable(fmt.Sprintf("cannot add %q to a value that is not an object or an array", token))
+		}
+	})
+}
+
+// remove takes a member out of an object or an element out of an array and returns it
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+
+	var removed interface{}
+	doc, err := update(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			value, ok := p[token]
+			if !ok {
+				return nil, unapplicable(fmt.Sprintf("%q does not exist", token))
+			}
+			removed = value
+			delete(p, token)
+			return p, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(p)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = p[i]
+			return append(p[:i], p[i+1:]...), nil
+		default:
+			return nil, unapplicable(fmt.Sprintf("%q does not exist", token))
+		}
+	})
+	return doc, removed, err
+}
+
+// update walks to the parent of the last token of path and replaces it with what fn makes of it
+func update(node interface{}, path []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, unapplicable(fmt.Sprintf("%q does not exist", path[0]))
+		}
+		child, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = child
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(path[0], len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		child, err := update(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	default:
+		return nil, unapplicable(fmt.Sprintf("%q does not exist", path[0]))
+	}
+}
+
+// arrayIndex reads an array index up to max, leading zeros are not allowed
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, invalid(fmt.Sprintf("%q is not an array index", token))
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i > max {
+		return 0, unapplicable(fmt.Sprintf("index %s is out of range", token))
+	}
+	return i, nil
+}
+
+// equal compares JSON values, numbers are equal when their values are
+func equal(a, b interface{}) bool {
+	x, xok := a.(json.Number)
+	y, yok := b.(json.Number)
+	if xok && yok {
+		if x == y {
+			return true
+		}
+		xf, xerr := x.Float64()
+		yf, yerr := y.Float64()
+		return xerr == nil && yerr == nil && xf == yf
+	}
+
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for name, value := range x {
+			other, ok := y[name]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func clone(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+// decode reads exactly one JSON value, numbers are kept as they were written
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return value, nil
+}
+
+func encode(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func invalid(msg string) error {
+	return &Error{Msg: msg, Err: ErrInvalidPatch}
+}
+
+func unapplicable(msg string) error {
+	return &Error{Msg: msg, Err: ErrUnapplicable}
+}
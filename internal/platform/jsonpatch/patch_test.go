@@ -0,0 +1,140 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const document = `{"title":"Write report","description":"draft","done":false,"tags":["work","q3"],"meta":{"a/b":1,"m~n":2}}`
+
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+
+	var g, w interface{}
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("result is not JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("want is not JSON: %v", err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{"replace", `[{"op":"replace","path":"/title","value":"Ship report"}]`, `{"title":"Ship report","description":"draft","done":false,"tags":["work","q3"],"meta":{"a/b":1,"m~n":2}}`},
+		{"remove member", `[{"op":"remove","path":"/description"}]`, `{"title":"Write report","done":false,"tags":["work","q3"],"meta":{"a/b":1,"m~n":2}}`},
+		{"add member", `[{"op":"add","path":"/priority","value":"high"}]`, `{"title":"Write report","description":"draft","done":false,"priority":"high","tags":["work","q3"],"meta":{"a/b":1,"m~n":2}}`},
+		{"add null", `[{"op":"add","path":"/description","value":null}]`, `{"title":"Write report","description":null,"done":false,"tags":["work","q3"],"meta":{"a/b":1,"m~n":2}}`},
+		{"append", `[{"op":"add","path":"/tags/-","value":"urgent"}]`, `{"title":"Write report","description":"draft","done":false,"tags":["work","q3","urgent"],"meta":{"a/b":1,"m~n":2}}`},
+		{"insert", `[{"op":"add","path":"/tags/1","value":"urgent"}]`, `{"title":"Write report","description":"draft","done":false,"tags":["work","urgent","q3"],"meta":{"a/b":1,"m~n":2}}`},
+		{"remove element", `[{"op":"remove","path":"/tags/0"}]`, `{"title":"Write report","description":"draft","done":false,"tags":["q3"],"meta":{"a/b":1,"m~n":2}}`},
+		{"escaped tokens", `[{"op":"remove","path":"/meta/a~1b"},{"op":"replace","path":"/meta/m~0n","value":3}]`, `{"title":"Write report","description":"draft","done":false,"tags":["work","q3"],"meta":{"m~n":3}}`},
+		{"move", `[{"op":"move","from":"/tags/0","path":"/tags/-"}]`, `{"title":"Write report","description":"draft","done":false,"tags":["q3","work"],"meta":{"a/b":1,"m~n":2}}`},
+		{"copy", `[{"op":"copy","from":"/title","path":"/description"}]`, `{"title":"Write report","description":"Write report","done":false,"tags":["work","q3"],"meta":{"a/b":1,"m~n":2}}`},
+		{"test then replace", `[{"op":"test","path":"/done","value":false},{"op":"replace","path":"/done","value":true}]`, `{"title":"Write report","description":"draft","done":true,"tags":["work","q3"],"meta":{"a/b":1,"m~n":2}}`},
+		{"test numbers by value", `[{"op":"test","path":"/meta/m~0n","value":2.0}]`, document},
+		{"replace whole document", `[{"op":"replace","path":"","value":{"title":"New"}}]`, `{"title":"New"}`},
+		{"empty patch", `[]`, document},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(document), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  error
+		index int
+	}{
+		{"not an array", `{"op":"remove","path":"/title"}`, ErrInvalidPatch, 0},
+		{"unknown op", `[{"op":"merge","path":"/title"}]`, ErrInvalidPatch, 0},
+		{"missing path", `[{"op":"remove"}]`, ErrInvalidPatch, 0},
+		{"missing value", `[{"op":"add","path":"/title"}]`, ErrInvalidPatch, 0},
+		{"missing from", `[{"op":"copy","path":"/title"}]`, ErrInvalidPatch, 0},
+		{"relative path", `[{"op":"remove","path":"title"}]`, ErrInvalidPatch, 0},
+		{"leading zero index", `[{"op":"remove","path":"/tags/01"}]`, ErrInvalidPatch, 0},
+		{"move into itself", `[{"op":"move","from":"/meta","path":"/meta/x"}]`, ErrInvalidPatch, 0},
+		{"remove missing member", `[{"op":"remove","path":"/priority"}]`, ErrUnapplicable, 0},
+		{"replace missing member", `[{"op":"replace","path":"/priority","value":"low"}]`, ErrUnapplicable, 0},
+		{"index out of range", `[{"op":"add","path":"/tags/3","value":"x"}]`, ErrUnapplicable, 0},
+		{"missing parent", `[{"op":"add","path":"/a/b","value":1}]`, ErrUnapplicable, 0},
+		{"failed test", `[{"op":"replace","path":"/done","value":true},{"op":"test","path":"/title","value":"Other"}]`, ErrUnapplicable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Apply([]byte(document), []byte(tt.patch))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Apply() error = %v, want %v", err, tt.want)
+			}
+			var e *Error
+			if errors.As(err, &e) && e.Index != tt.index {
+				t.Errorf("Apply() error index = %d, want %d", e.Index, tt.index)
+			}
+		})
+	}
+}
+
+func TestApplyLeavesDocumentOnFailure(t *testing.T) {
+	doc := []byte(document)
+	if _, err := Apply(doc, []byte(`[{"op":"remove","path":"/title"},{"op":"test","path":"/done","value":true}]`)); err == nil {
+		t.Fatal("Apply() error = nil, want a failed test")
+	}
+	if string(doc) != document {
+		t.Errorf("Apply() changed the document to %s", doc)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		want     string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null removes", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"arrays are replaced", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"nested objects merge", `{"a":{"b":"c","d":"e"}}`, `{"a":{"d":null,"f":"g"}}`, `{"a":{"b":"c","f":"g"}}`},
+		{"object replaces scalar", `{"a":"b"}`, `{"a":{"c":null,"d":1}}`, `{"a":{"d":1}}`},
+		{"non object patch replaces", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"empty patch", `{"a":"b"}`, `{}`, `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.document), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("MergePatch() error = %v", err)
+			}
+			assertJSON(t, got, tt.want)
+		})
+	}
+}
+
+func TestMergePatchInvalid(t *testing.T) {
+	for _, patch := range []string{``, `{"a":`, `{"a":1} {"b":2}`} {
+		if _, err := MergePatch([]byte(`{}`), []byte(patch)); !errors.Is(err, ErrInvalidPatch) {
+			t.Errorf("MergePatch(%q) error = %v, want %v", patch, err, ErrInvalidPatch)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package jwt
+
+import (
+	"errors"
+	"github.com/savioruz/mikti-task/internal/platform/cache"
+	"time"
+)
+
+// Denylist keeps the IDs of tokens that were revoked before they expired
+type Denylist interface {
+	// Add denies a token for ttl, which should be at least the time the token has left
+	Add(tokenID string, ttl time.Duration) error
+	Contains(tokenID string) (bool, error)
+}
+
+type CacheDenylist struct {
+	cache cache.Cache
+}
+
+func NewCacheDenylist(cache cache.Cache) *CacheDenylist {
+	return &CacheDenylist{
+		cache: cache,
+	}
+}
+
+func (d *CacheDenylist) Add(tokenID string, ttl time.Duration) error {
+	// A token that expired already is denied anyway, and no expiry would keep the key forever
+	if ttl <= 0 {
+		return nil
+	}
+	return d.cache.Set(denylistKey(tokenID), true, ttl)
+}
+
+func (d *CacheDenylist) Contains(tokenID string) (bool, error) {
+	var denied bool
+	err := d.cache.Get(denylistKey(tokenID), &denied)
+	if errors.Is(err, cache.ErrCacheMiss) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return denied, nil
+}
+
+func denylistKey(tokenID string) string {
+	return "jwt:denylist:" + tokenID
+}
@@ -0,0 +1,15 @@
+package jwt
+
+import "errors"
+
+var (
+	ErrInvalidToken     = errors.New("jwt: invalid token")
+	ErrSigningMethod    = errors.New("jwt: unexpected signing method")
+	ErrTokenExpired     = errors.New("jwt: token has expired")
+	ErrTokenNotValidYet = errors.New("jwt: token is not valid yet")
+	ErrInvalidIssuer    = errors.New("jwt: invalid issuer")
+	ErrInvalidAudience  = errors.New("jwt: invalid audience")
+	ErrTokenType        = errors.New("jwt: unexpected token type")
+	ErrUnknownKey       = errors.New("jwt: unknown key")
+	ErrUnsupportedKey   = errors.New("jwt: unsupported key")
+)
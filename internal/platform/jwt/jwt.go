@@ -1,7 +1,16 @@
 package jwt
 
+import "time"
+
 type JWTService interface {
-	GenerateAccessToken(userID, email, role string) (string, error)
-	GenerateRefreshToken(userID, email, role string) (string, error)
-	ValidateToken(tokenString string) (*JWTClaims, error)
+	GenerateAccessToken(userID, email, role, workspaceID, sessionID string) (string, error)
+	// GenerateRefreshToken issues a refresh token of a session, tokenID becomes its jti and tells apart the
+	// tokens rotated from the same session
+	GenerateRefreshToken(userID, email, role, workspaceID, sessionID, tokenID string) (string, error)
+	// GenerateVerificationToken issues a token that confirms the email of a user, it is only good for that
+	GenerateVerificationToken(userID, email string, expiry time.Duration) (string, error)
+	// ValidateToken accepts only tokens of tokenType, one of the TokenType constants
+	ValidateToken(tokenString, tokenType string) (*JWTClaims, error)
+	// JWKS lists the public keys tokens are verified with, it is empty for tokens signed with a shared secret
+	JWKS() *JWKS
 }
@@ -1,21 +1,44 @@
 package jwt
 
 import (
+	"crypto"
 	"errors"
+	"fmt"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"time"
 )
 
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	// TokenTypeVerification is sent by email to confirm the address, it carries no role
+	TokenTypeVerification = "verify"
+)
+
 type JWTConfig struct {
 	Secret        string
 	AccessExpiry  time.Duration
 	RefreshExpiry time.Duration
+	// Issuer and Audience are written to every token and checked on validation when set
+	Issuer   string
+	Audience string
+	// PrivateKeyFile is a PEM encoded RSA or Ed25519 key that signs tokens with RS256 or EdDSA instead of the
+	// secret. PublicKeyFiles are further keys tokens are still accepted from, like the one rotated out.
+	PrivateKeyFile string
+	PublicKeyFiles []string
 }
 
 type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// WorkspaceID is the workspace chosen at login, requests may still pick another one with a header
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	// SessionID is the login the token was issued for
+	SessionID string `json:"sid,omitempty"`
+	// Type keeps refresh tokens from being used as access tokens and the other way round
+	Type string `json:"typ"`
 	jwt.RegisteredClaims
 }
 
@@ -23,51 +46,182 @@ type JWTServiceImpl struct {
 	secretKey     []byte
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
+	issuer        string
+	audience      string
+	// signingKey signs with its own method and kid, tokens are signed with HS256 and the secret without it
+	signingKey crypto.Signer
+	signer     *verificationKey
+	// keys are the public keys by kid, the signer's first
+	keys []*verificationKey
 }
 
-func NewJWTService(config *JWTConfig) *JWTServiceImpl {
-	return &JWTServiceImpl{
+func NewJWTService(config *JWTConfig) (*JWTServiceImpl, error) {
+	s := &JWTServiceImpl{
 		secretKey:     []byte(config.Secret),
 		accessExpiry:  config.AccessExpiry,
 		refreshExpiry: config.RefreshExpiry,
+		issuer:        config.Issuer,
+		audience:      config.Audience,
+	}
+
+	if config.PrivateKeyFile == "" {
+		if len(config.PublicKeyFiles) > 0 {
+			return nil, fmt.Errorf("%w: public keys need a private key to sign with", ErrUnsupportedKey)
+		}
+		return s, nil
+	}
+
+	signingKey, err := loadPrivateKey(config.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := newVerificationKey(signingKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	s.signingKey = signingKey
+	s.signer = signer
+	s.keys = append(s.keys, signer)
+
+	for _, path := range config.PublicKeyFiles {
+		public, err := loadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := newVerificationKey(public)
+		if err != nil {
+			return nil, err
+		}
+		if s.key(key.kid) == nil {
+			s.keys = append(s.keys, key)
+		}
 	}
+
+	return s, nil
+}
+
+func (s *JWTServiceImpl) GenerateAccessToken(userID, email, role, workspaceID, sessionID string) (string, error) {
+	// Access tokens get an ID of their own, so a single one can be denied on logout
+	return s.generateToken(TokenTypeAccess, userID, email, role, workspaceID, sessionID, uuid.NewString(), s.accessExpiry)
 }
 
-func (s *JWTServiceImpl) GenerateAccessToken(userID, email, role string) (string, error) {
-	return s.generateToken(userID, email, role, s.accessExpiry)
+func (s *JWTServiceImpl) GenerateRefreshToken(userID, email, role, workspaceID, sessionID, tokenID string) (string, error) {
+	return s.generateToken(TokenTypeRefresh, userID, email, role, workspaceID, sessionID, tokenID, s.refreshExpiry)
 }
 
-func (s *JWTServiceImpl) GenerateRefreshToken(userID, email, role string) (string, error) {
-	return s.generateToken(userID, email, role, s.refreshExpiry)
+func (s *JWTServiceImpl) GenerateVerificationToken(userID, email string, expiry time.Duration) (string, error) {
+	return s.generateToken(TokenTypeVerification, userID, email, "", "", "", uuid.NewString(), expiry)
 }
 
-func (s *JWTServiceImpl) generateToken(userID, email, role string, expiry time.Duration) (string, error) {
+func (s *JWTServiceImpl) generateToken(tokenType, userID, email, role, workspaceID, sessionID, tokenID string, expiry time.Duration) (string, error) {
+	now := time.Now()
 	claims := &JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:      userID,
+		Email:       email,
+		Role:        role,
+		WorkspaceID: workspaceID,
+		SessionID:   sessionID,
+		Type:        tokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        tokenID,
+			Issuer:    s.issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	if s.signer == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(s.secretKey)
+	}
+
+	token := jwt.NewWithClaims(s.signer.method, claims)
+	token.Header["kid"] = s.signer.kid
+	return token.SignedString(s.signingKey)
 }
 
-func (s *JWTServiceImpl) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return s.secretKey, nil
-	})
+// ValidateToken checks the signature and the registered claims of a token and that it is of tokenType. The
+// algorithm is pinned to HS256, or to the one of the key named by the kid, so neither "none" nor a public key
+// passed off as a secret are accepted.
+func (s *JWTServiceImpl) ValidateToken(tokenString, tokenType string) (*JWTClaims, error) {
+	options := []jwt.ParserOption{jwt.WithExpirationRequired(), jwt.WithIssuedAt()}
+	if s.issuer != "" {
+		options = append(options, jwt.WithIssuer(s.issuer))
+	}
+	if s.audience != "" {
+		options = append(options, jwt.WithAudience(s.audience))
+	}
+
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, s.verifyingKey, options...)
 
 	if err != nil {
-		return nil, err
+		switch {
+		case errors.Is(err, ErrSigningMethod):
+			return nil, ErrSigningMethod
+		case errors.Is(err, ErrUnknownKey):
+			return nil, ErrUnknownKey
+		case errors.Is(err, jwt.ErrTokenExpired):
+			return nil, ErrTokenExpired
+		case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+			return nil, ErrTokenNotValidYet
+		case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+			return nil, ErrInvalidIssuer
+		case errors.Is(err, jwt.ErrTokenInvalidAudience):
+			return nil, ErrInvalidAudience
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		}
+	}
+
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid || claims.ID == "" {
+		return nil, ErrInvalidToken
 	}
 
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
+	if claims.Type != tokenType {
+		return nil, ErrTokenType
 	}
 
-	return nil, errors.New("invalid token")
+	return claims, nil
+}
+
+// verifyingKey returns the key to check the signature of a token with
+func (s *JWTServiceImpl) verifyingKey(token *jwt.Token) (interface{}, error) {
+	if s.signer == nil {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("%w: %v", ErrSigningMethod, token.Header["alg"])
+		}
+		return s.secretKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key := s.key(kid)
+	if key == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("%w: %v", ErrSigningMethod, token.Header["alg"])
+	}
+	return key.public, nil
+}
+
+func (s *JWTServiceImpl) key(kid string) *verificationKey {
+	for _, key := range s.keys {
+		if key.kid == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+func (s *JWTServiceImpl) JWKS() *JWKS {
+	jwks := &JWKS{Keys: make([]JWK, len(s.keys))}
+	for i, key := range s.keys {
+		jwks.Keys[i] = key.jwk()
+	}
+	return jwks
 }
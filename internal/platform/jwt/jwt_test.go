@@ -0,0 +1,123 @@
+package jwt
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"testing"
+	"time"
+)
+
+func newTestService(t *testing.T) *JWTServiceImpl {
+	s, err := NewJWTService(&JWTConfig{
+		Secret:        "secret",
+		AccessExpiry:  time.Hour,
+		RefreshExpiry: 24 * time.Hour,
+		Issuer:        "mikti-task",
+		Audience:      "mikti-task",
+	})
+	if err != nil {
+		t.Fatalf("NewJWTService() error = %v", err)
+	}
+	return s
+}
+
+func TestValidateTokenType(t *testing.T) {
+	s := newTestService(t)
+
+	access, err := s.GenerateAccessToken("user", "user@example.com", "user", "", "session")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+	refresh, err := s.GenerateRefreshToken("user", "user@example.com", "user", "", "session", "token")
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken() error = %v", err)
+	}
+
+	claims, err := s.ValidateToken(access, TokenTypeAccess)
+	if err != nil {
+		t.Fatalf("ValidateToken(access) error = %v", err)
+	}
+	if claims.ID == "" || claims.SessionID != "session" || claims.Issuer != "mikti-task" || claims.NotBefore == nil {
+		t.Errorf("ValidateToken(access) claims = %+v", claims)
+	}
+
+	claims, err = s.ValidateToken(refresh, TokenTypeRefresh)
+	if err != nil {
+		t.Fatalf("ValidateToken(refresh) error = %v", err)
+	}
+	if claims.ID != "token" {
+		t.Errorf("ValidateToken(refresh) jti = %q, want token", claims.ID)
+	}
+
+	if _, err := s.ValidateToken(refresh, TokenTypeAccess); !errors.Is(err, ErrTokenType) {
+		t.Errorf("ValidateToken(refresh as access) error = %v, want ErrTokenType", err)
+	}
+	if _, err := s.ValidateToken(access, TokenTypeRefresh); !errors.Is(err, ErrTokenType) {
+		t.Errorf("ValidateToken(access as refresh) error = %v, want ErrTokenType", err)
+	}
+}
+
+func TestValidateTokenRejects(t *testing.T) {
+	s := newTestService(t)
+	now := time.Now()
+
+	claims := func(modify func(c *JWTClaims)) *JWTClaims {
+		c := &JWTClaims{
+			UserID: "user",
+			Type:   TokenTypeAccess,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "token",
+				Issuer:    "mikti-task",
+				Audience:  jwt.ClaimStrings{"mikti-task"},
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+				NotBefore: jwt.NewNumericDate(now),
+				IssuedAt:  jwt.NewNumericDate(now),
+			},
+		}
+		modify(c)
+		return c
+	}
+	sign := func(method jwt.SigningMethod, key interface{}, c *JWTClaims) string {
+		token, err := jwt.NewWithClaims(method, c).SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"none", sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims(func(*JWTClaims) {})), ErrSigningMethod},
+		{"HS384", sign(jwt.SigningMethodHS384, []byte("secret"), claims(func(*JWTClaims) {})), ErrSigningMethod},
+		{"wrong secret", sign(jwt.SigningMethodHS256, []byte("other"), claims(func(*JWTClaims) {})), ErrInvalidToken},
+		{"expired", sign(jwt.SigningMethodHS256, []byte("secret"), claims(func(c *JWTClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+		})), ErrTokenExpired},
+		{"not before", sign(jwt.SigningMethodHS256, []byte("secret"), claims(func(c *JWTClaims) {
+			c.NotBefore = jwt.NewNumericDate(now.Add(time.Hour))
+		})), ErrTokenNotValidYet},
+		{"issuer", sign(jwt.SigningMethodHS256, []byte("secret"), claims(func(c *JWTClaims) {
+			c.Issuer = "other"
+		})), ErrInvalidIssuer},
+		{"audience", sign(jwt.SigningMethodHS256, []byte("secret"), claims(func(c *JWTClaims) {
+			c.Audience = jwt.ClaimStrings{"other"}
+		})), ErrInvalidAudience},
+		{"no jti", sign(jwt.SigningMethodHS256, []byte("secret"), claims(func(c *JWTClaims) {
+			c.ID = ""
+		})), ErrInvalidToken},
+		{"no type", sign(jwt.SigningMethodHS256, []byte("secret"), claims(func(c *JWTClaims) {
+			c.Type = ""
+		})), ErrTokenType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.ValidateToken(tt.token, TokenTypeAccess); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateToken() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
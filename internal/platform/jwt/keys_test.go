@@ -0,0 +1,130 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeKey(t *testing.T, name string, key crypto.Signer, public bool) string {
+	t.Helper()
+
+	var block *pem.Block
+	if public {
+		der, err := x509.MarshalPKIXPublicKey(key.Public())
+		if err != nil {
+			t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+		}
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	} else {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestKeyRotation(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	config := &JWTConfig{AccessExpiry: time.Hour, RefreshExpiry: time.Hour}
+
+	config.PrivateKeyFile = writeKey(t, "old.pem", rsaKey, false)
+	old, err := NewJWTService(config)
+	if err != nil {
+		t.Fatalf("NewJWTService(RS256) error = %v", err)
+	}
+	oldToken, err := old.GenerateAccessToken("user", "user@example.com", "user", "", "session")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+
+	// The Ed25519 key takes over signing while tokens of the RSA key keep working
+	config.PrivateKeyFile = writeKey(t, "new.pem", edKey, false)
+	config.PublicKeyFiles = []string{writeKey(t, "old.pub", rsaKey, true)}
+	current, err := NewJWTService(config)
+	if err != nil {
+		t.Fatalf("NewJWTService(EdDSA) error = %v", err)
+	}
+	newToken, err := current.GenerateAccessToken("user", "user@example.com", "user", "", "session")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := current.ValidateToken(token, TokenTypeAccess); err != nil {
+			t.Errorf("ValidateToken(%s) error = %v", name, err)
+		}
+	}
+	if _, err := old.ValidateToken(newToken, TokenTypeAccess); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("ValidateToken(new with old keys) error = %v, want ErrUnknownKey", err)
+	}
+
+	jwks := current.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].Alg != "EdDSA" || jwks.Keys[0].Kty != "OKP" || jwks.Keys[1].Alg != "RS256" || jwks.Keys[1].Kty != "RSA" {
+		t.Fatalf("JWKS() = %+v", jwks)
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &JWTClaims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+	if parsed.Header["kid"] != jwks.Keys[0].Kid || parsed.Method.Alg() != "EdDSA" {
+		t.Errorf("header = %v, want kid %s", parsed.Header, jwks.Keys[0].Kid)
+	}
+
+	// The public key passed off as an HMAC secret must not verify
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, parsed.Claims)
+	forged.Header["kid"] = jwks.Keys[1].Kid
+	pub, err := os.ReadFile(config.PublicKeyFiles[0])
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	forgedToken, err := forged.SignedString(pub)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	if _, err := current.ValidateToken(forgedToken, TokenTypeAccess); !errors.Is(err, ErrSigningMethod) {
+		t.Errorf("ValidateToken(forged) error = %v, want ErrSigningMethod", err)
+	}
+}
+
+func TestThumbprint(t *testing.T) {
+	// The example key of RFC 7638 section 3.1
+	jwk := JWK{
+		Kty: "RSA",
+		E:   "AQAB",
+		N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+	}
+
+	kid, err := thumbprint(jwk)
+	if err != nil {
+		t.Fatalf("thumbprint() error = %v", err)
+	}
+	if want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"; kid != want {
+		t.Errorf("thumbprint() = %s, want %s", kid, want)
+	}
+}
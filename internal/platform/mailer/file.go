@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer drops every message as an .eml file into a directory, so tests and staging can read what was sent
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+func (m *FileMailer) Send(_ context.Context, message *Message) error {
+	data, err := message.encode(m.from)
+	if err != nil {
+		return err
+	}
+
+	// The time first keeps the files in the order they were sent
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), uuid.NewString())
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o644)
+}
@@ -0,0 +1,25 @@
+package mailer
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+)
+
+// LogMailer writes messages to the log instead of sending them, for development
+type LogMailer struct {
+	log *logrus.Logger
+}
+
+func NewLogMailer(log *logrus.Logger) *LogMailer {
+	return &LogMailer{
+		log: log,
+	}
+}
+
+func (m *LogMailer) Send(_ context.Context, message *Message) error {
+	m.log.WithFields(logrus.Fields{
+		"to":      message.To,
+		"subject": message.Subject,
+	}).Info(message.Body)
+	return nil
+}
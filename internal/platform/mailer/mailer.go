@@ -0,0 +1,50 @@
+// Package mailer sends plain text emails through a backend that can be swapped through configuration.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+var ErrInvalidMessage = errors.New("mailer: invalid message")
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	// Send delivers a message or hands it to a relay, it does not wait for the recipient to read it
+	Send(ctx context.Context, message *Message) error
+}
+
+// encode renders a message as an RFC 5322 email from the given sender, header values with line breaks are
+// rejected so a recipient or subject cannot add headers of its own
+func (m *Message) encode(from string) ([]byte, error) {
+	for _, value := range []string{from, m.To, m.Subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, ErrInvalidMessage
+		}
+	}
+	if m.To == "" {
+		return nil, fmt.Errorf("%w: no recipient", ErrInvalidMessage)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewFileMailer(dir, "no-reply@example.com")
+	if err != nil {
+		t.Fatalf("NewFileMailer() error = %v", err)
+	}
+
+	message := &Message{To: "user@example.com", Subject: "Verify your email", Body: "Open the link:\nhttps://example.com"}
+	if err := m.Send(context.Background(), message); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Glob() = %v, %v, want one file", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"From: no-reply@example.com\r\n",
+		"To: user@example.com\r\n",
+		"Subject: Verify your email\r\n",
+		"\r\n\r\nOpen the link:\r\nhttps://example.com",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("message = %q, want it to contain %q", data, want)
+		}
+	}
+}
+
+func TestMessageHeaderInjection(t *testing.T) {
+	m, err := NewFileMailer(t.TempDir(), "no-reply@example.com")
+	if err != nil {
+		t.Fatalf("NewFileMailer() error = %v", err)
+	}
+
+	for _, message := range []*Message{
+		{To: "user@example.com\r\nBcc: other@example.com", Subject: "Hi"},
+		{To: "user@example.com", Subject: "Hi\nBcc: other@example.com"},
+		{To: "", Subject: "Hi"},
+	} {
+		if err := m.Send(context.Background(), message); !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("Send(%+v) error = %v, want ErrInvalidMessage", message, err)
+		}
+	}
+}
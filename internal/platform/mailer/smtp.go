@@ -0,0 +1,83 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"strconv"
+)
+
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username and Password are sent with PLAIN auth when set, which needs STARTTLS unless the host is local
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer hands messages to an SMTP relay and upgrades the connection with STARTTLS when it is offered
+type SMTPMailer struct {
+	config *SMTPConfig
+}
+
+func NewSMTPMailer(config *SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{
+		config: config,
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message *Message) error {
+	data, err := message.encode(m.config.From)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return err
+		}
+	}
+	if m.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,48 @@
+// Package markdown renders user written Markdown to HTML that is safe to embed in a page.
+package markdown
+
+import (
+	"bytes"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Raw HTML in the source is dropped by the renderer, the policy removes anything unsafe that is left
+	renderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	policy   = newPolicy()
+	// inputTag finds the inputs left after sanitizing, their attributes are quoted and escaped by then
+	inputTag = regexp.MustCompile(`<input[^>]*>`)
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// Keep the checkboxes of GFM task lists
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// Render converts Markdown to sanitized HTML
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+
+	return sanitize(buf.String()), nil
+}
+
+// sanitize applies the policy and drops the inputs that are not checkboxes. The policy strips a type other than
+// checkbox but keeps the input, which would then show up as a text field.
+func sanitize(html string) string {
+	return inputTag.ReplaceAllStringFunc(policy.Sanitize(html), func(tag string) string {
+		if strings.Contains(tag, ` type="checkbox"`) {
+			return tag
+		}
+		return ""
+	})
+}
@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+		reject []string
+	}{
+		{"script tag", "<script>alert(1)</script>\n\ntext", []string{"<p>text</p>"}, []string{"<script", "alert"}},
+		{"inline script", "text <script>alert(1)</script>", []string{"text"}, []string{"<script"}},
+		{"iframe", `<iframe src="https://example.com"></iframe>`, nil, []string{"<iframe"}},
+		{"javascript link", "[click](javascript:alert(1))", []string{"click"}, []string{"javascript:", "<a"}},
+		{"javascript html link", `<a href="javascript:alert(1)">click</a>`, nil, []string{"javascript:"}},
+		{"event attribute", `<img src="x.png" onerror="alert(1)">`, nil, []string{"onerror", "alert"}},
+		{"link", "[docs](https://example.com)", []string{`<a href="https://example.com" rel="nofollow">docs</a>`}, nil},
+		{"emphasis", "**bold** and _italic_", []string{"<strong>bold</strong>", "<em>italic</em>"}, nil},
+		{"task list", "- [x] done\n- [ ] open", []string{
+			`<input checked="" disabled="" type="checkbox"> done`,
+			`<input disabled="" type="checkbox"> open`,
+		}, nil},
+		{"raw input", `<input type="text" value="secret">`, nil, []string{"<input"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.source)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Render(%q) = %q, want it to contain %q", tt.source, got, want)
+				}
+			}
+			for _, reject := range tt.reject {
+				if strings.Contains(got, reject) {
+					t.Errorf("Render(%q) = %q, must not contain %q", tt.source, got, reject)
+				}
+			}
+		})
+	}
+}
+
+// The renderer drops raw HTML already, the sanitizer has to hold up on its own as well
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"script", `<p>a</p><script>alert(1)</script>`, `<p>a</p>`},
+		{"iframe", `<iframe src="https://example.com"></iframe>`, ``},
+		{"javascript link", `<a href="javascript:alert(1)">a</a>`, `a`},
+		{"event attributes", `<p onclick="alert(1)" onmouseover="alert(1)">a</p>`, `<p>a</p>`},
+		{"checkbox", `<input type="checkbox" checked="" disabled="" onclick="alert(1)">`, `<input type="checkbox" checked="" disabled="">`},
+		{"text input", `<input type="text" value="a">`, ``},
+		{"hidden input", `<input type="hidden" name="a">`, ``},
+		{"uppercase type", `<input type="CHECKBOX">`, ``},
+		{"no type", `<input disabled="">`, ``},
+		{"text input with checkbox attributes", `<input type="text" checked="" disabled="">`, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitize(tt.html); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
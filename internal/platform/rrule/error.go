@@ -0,0 +1,8 @@
+package rrule
+
+import "errors"
+
+var (
+	ErrInvalidRule     = errors.New("rrule: invalid rule")
+	ErrUnsupportedRule = errors.New("rrule: unsupported rule part")
+)
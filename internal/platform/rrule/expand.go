@@ -0,0 +1,305 @@
+package rrule
+
+import (
+	"slices"
+	"time"
+)
+
+// maxEmptyPeriods bounds the search for rules that rarely or never match, such as BYMONTH=2;BYMONTHDAY=30
+const maxEmptyPeriods = 1000
+
+// Next returns the first occurrence strictly after the given time, or false when the series has ended.
+// The series starts at dtstart and is expanded in dtstart's location, so occurrences keep their wall clock time across DST changes.
+func (r *Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+
+	r.iterate(dtstart, func(t time.Time) bool {
+		if t.After(after) {
+			next = t
+			found = true
+			return false
+		}
+		return true
+	})
+
+	return next, found
+}
+
+// Occurrences returns up to n occurrences of the series starting at dtstart, dtstart itself being the first
+func (r *Rule) Occurrences(dtstart time.Time, n int) []time.Time {
+	var occurrences []time.Time
+	if n <= 0 {
+		return occurrences
+	}
+
+	r.iterate(dtstart, func(t time.Time) bool {
+		occurrences = append(occurrences, t)
+		return len(occurrences) < n
+	})
+
+	return occurrences
+}
+
+// iterate calls yield with each occurrence in order until it returns false or the series ends
+func (r *Rule) iterate(dtstart time.Time, yield func(time.Time) bool) {
+	loc := dtstart.Location()
+	until := r.until(loc)
+	count := 0
+
+	emit := func(t time.Time) bool {
+		if until != nil && t.After(*until) {
+			return false
+		}
+		count++
+		if !yield(t) {
+			return false
+		}
+		return r.Count == 0 || count < r.Count
+	}
+
+	// DTSTART is always the first occurrence of the series
+	if !emit(dtstart) {
+		return
+	}
+
+	hour, minute, sec := dtstart.Clock()
+	start := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+
+	for period, empty := 0, 0; empty < maxEmptyPeriods; period++ {
+		produced := false
+		for _, day := range r.periodDays(start, period) {
+			t := wallClock(day.Year(), day.Month(), day.Day(), hour, minute, sec, loc)
+			if !t.After(dtstart) {
+				continue
+			}
+			produced = true
+			if !emit(t) {
+				return
+			}
+		}
+
+		if produced {
+			empty = 0
+		} else {
+			empty++
+		}
+	}
+}
+
+// periodDays returns the sorted candidate days of the nth period after start, as UTC midnights
+func (r *Rule) periodDays(start time.Time, period int) []time.Time {
+	step := period * r.Interval
+
+	switch r.Freq {
+	case Daily:
+		day := start.AddDate(0, 0, step)
+		if r.matchesDay(day) {
+			return []time.Time{day}
+		}
+		return nil
+	case Weekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := start.AddDate(0, 0, 7*step-offset)
+
+		weekdays := r.ByDay
+		if len(weekdays) == 0 {
+			weekdays = []Weekday{{Day: start.Weekday()}}
+		}
+
+		var days []time.Time
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			if !r.matchesMonth(day.Month()) {
+				continue
+			}
+			for _, wd := range weekdays {
+				if wd.Day == day.Weekday() {
+					days = append(days, day)
+					break
+				}
+			}
+		}
+		return days
+	case Monthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		if !r.matchesMonth(first.Month()) {
+			return nil
+		}
+		return r.monthDays(first.Year(), first.Month(), start.Day())
+	case Yearly:
+		year := start.Year() + step
+
+		months := r.ByMonth
+		if len(months) == 0 {
+			if len(r.ByDay) > 0 || len(r.ByMonthDay) > 0 {
+				months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			} else {
+				months = []time.Month{start.Month()}
+			}
+		}
+		months = slices.Clone(months)
+		slices.Sort(months)
+
+		var days []time.Time
+		for _, month := range slices.Compact(months) {
+			days = append(days, r.monthDays(year, month, start.Day())...)
+		}
+		return days
+	}
+
+	return nil
+}
+
+// monthDays expands BYMONTHDAY and BYDAY within a month, falling back to the start day.
+// Days that do not exist in the month, such as the 31st of April, are skipped.
+func (r *Rule) monthDays(year int, month time.Month, defaultDay int) []time.Time {
+	n := daysIn(year, month)
+
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if defaultDay > n {
+			return nil
+		}
+		return []time.Time{time.Date(year, month, defaultDay, 0, 0, 0, 0, time.UTC)}
+	}
+
+	var byMonthDay, byDay map[int]bool
+
+	if len(r.ByMonthDay) > 0 {
+		byMonthDay = make(map[int]bool)
+		for _, md := range r.ByMonthDay {
+			if d := resolveMonthDay(md, n); d > 0 {
+				byMonthDay[d] = true
+			}
+		}
+	}
+
+	if len(r.ByDay) > 0 {
+		byDay = make(map[int]bool)
+		firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		for _, wd := range r.ByDay {
+			first := 1 + (int(wd.Day)-int(firstWeekday)+7)%7
+			switch {
+			case wd.N == 0:
+				for d := first; d <= n; d += 7 {
+					byDay[d] = true
+				}
+			case wd.N > 0:
+				if d := first + (wd.N-1)*7; d <= n {
+					byDay[d] = true
+				}
+			default:
+				last := first + (n-first)/7*7
+				if d := last + (wd.N+1)*7; d >= 1 {
+					byDay[d] = true
+				}
+			}
+		}
+	}
+
+	var days []time.Time
+	for d := 1; d <= n; d++ {
+		if byMonthDay != nil && !byMonthDay[d] {
+			continue
+		}
+		if byDay != nil && !byDay[d] {
+			continue
+		}
+		days = append(days, time.Date(year, month, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	return days
+}
+
+// matchesDay applies the BYxxx parts as filters, which is how they act on a daily rule
+func (r *Rule) matchesDay(day time.Time) bool {
+	if !r.matchesMonth(day.Month()) {
+		return false
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		n := daysIn(day.Year(), day.Month())
+		matched := false
+		for _, md := range r.ByMonthDay {
+			if resolveMonthDay(md, n) == day.Day() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(r.ByDay) > 0 {
+		matched := false
+		for _, wd := range r.ByDay {
+			if wd.Day == day.Weekday() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Rule) matchesMonth(month time.Month) bool {
+	return len(r.ByMonth) == 0 || slices.Contains(r.ByMonth, month)
+}
+
+func (r *Rule) until(loc *time.Location) *time.Time {
+	if r.Until == nil {
+		return nil
+	}
+
+	if !r.untilLocal {
+		return r.Until
+	}
+
+	u := *r.Until
+	t := wallClock(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), loc)
+	return &t
+}
+
+// wallClock builds a local time the way RFC 5545 resolves DST transitions.
+// A time skipped by a forward transition is read with the offset from before the gap, so 02:30 becomes 03:30,
+// and a repeated time during a backward transition resolves to its first occurrence.
+func wallClock(year int, month time.Month, day, hour, minute, sec int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, minute, sec, 0, loc)
+
+	_, offset := t.Add(-24 * time.Hour).Zone()
+	wall := time.Date(year, month, day, hour, minute, sec, 0, time.UTC)
+	before := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+
+	if !sameClock(t, day, hour, minute) {
+		return before
+	}
+	if sameClock(before, day, hour, minute) && before.Before(t) {
+		return before
+	}
+
+	return t
+}
+
+func sameClock(t time.Time, day, hour, minute int) bool {
+	return t.Day() == day && t.Hour() == hour && t.Minute() == minute
+}
+
+func resolveMonthDay(md, n int) int {
+	if md < 0 {
+		md = n + md + 1
+	}
+	if md < 1 || md > n {
+		return 0
+	}
+
+	return md
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
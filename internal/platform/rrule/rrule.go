@@ -0,0 +1,282 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used for repeating todos.
+package rrule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+const (
+	untilLayoutUTC   = "20060102T150405Z"
+	untilLayoutLocal = "20060102T150405"
+	untilLayoutDate  = "20060102"
+)
+
+// Weekday is a BYDAY entry, N selects the nth weekday of the month and is negative when counted from the end
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// Rule is a parsed RRULE, occurrences are expanded in the location of the series start
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+
+	// untilLocal marks an UNTIL without a UTC designator, it is read as wall clock time of the series
+	untilLocal bool
+}
+
+// Parse reads an RRULE value such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", with or without the "RRULE:" prefix
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	rule := &Rule{Freq: -1, Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: duplicate %s", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			err = rule.parseFreq(value)
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(value)
+		case "COUNT":
+			rule.Count, err = parsePositive(value)
+		case "UNTIL":
+			err = rule.parseUntil(value)
+		case "BYDAY":
+			err = rule.parseByDay(value)
+		case "BYMONTHDAY":
+			err = rule.parseByMonthDay(value)
+		case "BYMONTH":
+			err = rule.parseByMonth(value)
+		case "WKST":
+			rule.WeekStart, err = parseWeekday(value)
+		case "BYSECOND", "BYMINUTE", "BYHOUR", "BYYEARDAY", "BYWEEKNO", "BYSETPOS":
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedRule, key)
+		default:
+			return nil, fmt.Errorf("%w: unknown part %s", ErrInvalidRule, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRule, key, err)
+		}
+	}
+
+	if rule.Freq < 0 {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+	for _, wd := range rule.ByDay {
+		if wd.N == 0 {
+			continue
+		}
+		if rule.Freq != Monthly && (rule.Freq != Yearly || len(rule.ByMonth) == 0) {
+			return nil, fmt.Errorf("%w: BYDAY ordinals need FREQ=MONTHLY or FREQ=YEARLY with BYMONTH", ErrUnsupportedRule)
+		}
+	}
+	if rule.Freq == Weekly && len(rule.ByMonthDay) > 0 {
+		return nil, fmt.Errorf("%w: BYMONTHDAY is not allowed with FREQ=WEEKLY", ErrInvalidRule)
+	}
+
+	return rule, nil
+}
+
+// String returns the rule in canonical form, without the "RRULE:" prefix
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + frequencyNames[r.Freq]}
+
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if r.Until != nil {
+		if r.untilLocal {
+			parts = append(parts, "UNTIL="+r.Until.Format(untilLayoutLocal))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayoutUTC))
+		}
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = strconv.Itoa(int(m))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			if wd.N != 0 {
+				days[i] = strconv.Itoa(wd.N) + weekdayNames[wd.Day]
+			} else {
+				days[i] = weekdayNames[wd.Day]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func (r *Rule) parseFreq(value string) error {
+	for freq, name := range frequencyNames {
+		if name == value {
+			r.Freq = freq
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported frequency %q", value)
+}
+
+func (r *Rule) parseUntil(value string) error {
+	if t, err := time.Parse(untilLayoutUTC, value); err == nil {
+		r.Until = &t
+		return nil
+	}
+
+	for _, layout := range []string{untilLayoutLocal, untilLayoutDate} {
+		if t, err := time.Parse(layout, value); err == nil {
+			if layout == untilLayoutDate {
+				// a date-only UNTIL includes the whole day
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			r.Until = &t
+			r.untilLocal = true
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid date %q", value)
+}
+
+func (r *Rule) parseByDay(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return fmt.Errorf("invalid weekday %q", item)
+		}
+
+		day, err := parseWeekday(item[len(item)-2:])
+		if err != nil {
+			return err
+		}
+
+		wd := Weekday{Day: day}
+		if ordinal := item[:len(item)-2]; ordinal != "" {
+			n, err := strconv.Atoi(ordinal)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return fmt.Errorf("invalid weekday ordinal %q", item)
+			}
+			wd.N = n
+		}
+		r.ByDay = append(r.ByDay, wd)
+	}
+
+	return nil
+}
+
+func (r *Rule) parseByMonthDay(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		d, err := strconv.Atoi(item)
+		if err != nil || d == 0 || d < -31 || d > 31 {
+			return fmt.Errorf("invalid month day %q", item)
+		}
+		r.ByMonthDay = append(r.ByMonthDay, d)
+	}
+
+	return nil
+}
+
+func (r *Rule) parseByMonth(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		m, err := strconv.Atoi(item)
+		if err != nil || m < 1 || m > 12 {
+			return fmt.Errorf("invalid month %q", item)
+		}
+		r.ByMonth = append(r.ByMonth, time.Month(m))
+	}
+
+	return nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for day, name := range weekdayNames {
+		if name == value {
+			return day, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid weekday %q", value)
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("must be a positive integer, got %q", value)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,341 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load location %s: %v", name, err)
+	}
+
+	return loc
+}
+
+func mustParse(t *testing.T, s string) *Rule {
+	t.Helper()
+
+	rule, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", s, err)
+	}
+
+	return rule
+}
+
+func assertTimes(t *testing.T, got []time.Time, want []string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d occurrences %v, want %d %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i].Format(time.RFC3339) != want[i] {
+			t.Errorf("occurrence %d = %s, want %s", i, got[i].Format(time.RFC3339), want[i])
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"daily", "FREQ=DAILY", "FREQ=DAILY"},
+		{"prefix and case", "RRULE:freq=weekly;byday=mo,we", "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{"interval one is implied", "FREQ=DAILY;INTERVAL=1", "FREQ=DAILY"},
+		{"count", "FREQ=MONTHLY;INTERVAL=2;COUNT=5", "FREQ=MONTHLY;INTERVAL=2;COUNT=5"},
+		{"utc until", "FREQ=DAILY;UNTIL=20260131T090000Z", "FREQ=DAILY;UNTIL=20260131T090000Z"},
+		{"local until", "FREQ=DAILY;UNTIL=20260131T090000", "FREQ=DAILY;UNTIL=20260131T090000"},
+		{"date until", "FREQ=DAILY;UNTIL=20260131", "FREQ=DAILY;UNTIL=20260131T235959"},
+		{"ordinals", "FREQ=MONTHLY;BYDAY=-1FR,2MO", "FREQ=MONTHLY;BYDAY=-1FR,2MO"},
+		{"yearly", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH"},
+		{"week start", "FREQ=WEEKLY;INTERVAL=2;WKST=SU", "FREQ=WEEKLY;INTERVAL=2;WKST=SU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustParse(t, tt.in).String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{"empty", "", ErrInvalidRule},
+		{"missing freq", "INTERVAL=2", ErrInvalidRule},
+		{"unknown freq", "FREQ=HOURLY", ErrInvalidRule},
+		{"malformed part", "FREQ=DAILY;COUNT", ErrInvalidRule},
+		{"duplicate part", "FREQ=DAILY;FREQ=WEEKLY", ErrInvalidRule},
+		{"zero interval", "FREQ=DAILY;INTERVAL=0", ErrInvalidRule},
+		{"count and until", "FREQ=DAILY;COUNT=2;UNTIL=20260101T000000Z", ErrInvalidRule},
+		{"bad weekday", "FREQ=WEEKLY;BYDAY=XX", ErrInvalidRule},
+		{"bad month day", "FREQ=MONTHLY;BYMONTHDAY=32", ErrInvalidRule},
+		{"bad month", "FREQ=YEARLY;BYMONTH=13", ErrInvalidRule},
+		{"weekly month day", "FREQ=WEEKLY;BYMONTHDAY=1", ErrInvalidRule},
+		{"unknown part", "FREQ=DAILY;FOO=BAR", ErrInvalidRule},
+		{"by set pos", "FREQ=MONTHLY;BYDAY=MO;BYSETPOS=1", ErrUnsupportedRule},
+		{"weekly ordinal", "FREQ=WEEKLY;BYDAY=1MO", ErrUnsupportedRule},
+		{"yearly ordinal without month", "FREQ=YEARLY;BYDAY=1MO", ErrUnsupportedRule},
+		{"ordinal out of range", "FREQ=MONTHLY;BYDAY=6MO", ErrInvalidRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.in)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.in, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		n       int
+		want    []string
+	}{
+		{
+			name:    "daily with interval",
+			rule:    "FREQ=DAILY;INTERVAL=3;COUNT=3",
+			dtstart: time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC),
+			n:       10,
+			want:    []string{"2026-01-30T09:00:00Z", "2026-02-02T09:00:00Z", "2026-02-05T09:00:00Z"},
+		},
+		{
+			name:    "weekdays only",
+			rule:    "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart: time.Date(2026, 1, 9, 8, 0, 0, 0, time.UTC),
+			n:       3,
+			want:    []string{"2026-01-09T08:00:00Z", "2026-01-12T08:00:00Z", "2026-01-13T08:00:00Z"},
+		},
+		{
+			name:    "every other week on two days",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			dtstart: time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+			n:       5,
+			want: []string{
+				"2026-01-05T10:00:00Z", "2026-01-08T10:00:00Z",
+				"2026-01-19T10:00:00Z", "2026-01-22T10:00:00Z",
+				"2026-02-02T10:00:00Z",
+			},
+		},
+		{
+			name:    "week start changes the period",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SU;WKST=SU",
+			dtstart: time.Date(1997, 8, 5, 9, 0, 0, 0, time.UTC),
+			n:       4,
+			want:    []string{"1997-08-05T09:00:00Z", "1997-08-17T09:00:00Z", "1997-08-19T09:00:00Z", "1997-08-31T09:00:00Z"},
+		},
+		{
+			name:    "monthly on the 31st skips short months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC),
+			n:       3,
+			want:    []string{"2026-01-31T12:00:00Z", "2026-03-31T12:00:00Z", "2026-05-31T12:00:00Z"},
+		},
+		{
+			name:    "last day of the month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC),
+			n:       3,
+			want:    []string{"2026-01-31T12:00:00Z", "2026-02-28T12:00:00Z", "2026-03-31T12:00:00Z"},
+		},
+		{
+			name:    "last friday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: time.Date(2026, 1, 30, 17, 0, 0, 0, time.UTC),
+			n:       3,
+			want:    []string{"2026-01-30T17:00:00Z", "2026-02-27T17:00:00Z", "2026-03-27T17:00:00Z"},
+		},
+		{
+			name:    "friday the 13th",
+			rule:    "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			dtstart: time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC),
+			n:       3,
+			want:    []string{"2026-02-13T00:00:00Z", "2026-03-13T00:00:00Z", "2026-11-13T00:00:00Z"},
+		},
+		{
+			name:    "leap day",
+			rule:    "FREQ=YEARLY",
+			dtstart: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			n:       3,
+			want:    []string{"2024-02-29T00:00:00Z", "2028-02-29T00:00:00Z", "2032-02-29T00:00:00Z"},
+		},
+		{
+			name:    "thanksgiving",
+			rule:    "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			dtstart: time.Date(2025, 11, 27, 15, 0, 0, 0, time.UTC),
+			n:       3,
+			want:    []string{"2025-11-27T15:00:00Z", "2026-11-26T15:00:00Z", "2027-11-25T15:00:00Z"},
+		},
+		{
+			name:    "until is inclusive",
+			rule:    "FREQ=WEEKLY;UNTIL=20260119T090000Z",
+			dtstart: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+			n:       10,
+			want:    []string{"2026-01-05T09:00:00Z", "2026-01-12T09:00:00Z", "2026-01-19T09:00:00Z"},
+		},
+		{
+			name:    "impossible date ends the series",
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			n:       3,
+			want:    []string{"2026-01-01T00:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertTimes(t, mustParse(t, tt.rule).Occurrences(tt.dtstart, tt.n), tt.want)
+		})
+	}
+}
+
+func TestOccurrencesAcrossDST(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	berlin := mustLoad(t, "Europe/Berlin")
+	lordHowe := mustLoad(t, "Australia/Lord_Howe")
+	sydney := mustLoad(t, "Australia/Sydney")
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		n       int
+		want    []string
+	}{
+		{
+			name:    "daily keeps wall clock over spring forward",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 3, 7, 9, 0, 0, 0, newYork),
+			n:       3,
+			want:    []string{"2026-03-07T09:00:00-05:00", "2026-03-08T09:00:00-04:00", "2026-03-09T09:00:00-04:00"},
+		},
+		{
+			name:    "daily keeps wall clock over fall back",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 10, 31, 9, 0, 0, 0, newYork),
+			n:       3,
+			want:    []string{"2026-10-31T09:00:00-04:00", "2026-11-01T09:00:00-05:00", "2026-11-02T09:00:00-05:00"},
+		},
+		{
+			name:    "time skipped by spring forward moves past the gap",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 3, 7, 2, 30, 0, 0, newYork),
+			n:       3,
+			want:    []string{"2026-03-07T02:30:00-05:00", "2026-03-08T03:30:00-04:00", "2026-03-09T02:30:00-04:00"},
+		},
+		{
+			name:    "repeated time on fall back uses the first occurrence",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 10, 31, 1, 30, 0, 0, newYork),
+			n:       3,
+			want:    []string{"2026-10-31T01:30:00-04:00", "2026-11-01T01:30:00-04:00", "2026-11-02T01:30:00-05:00"},
+		},
+		{
+			name:    "weekly over european change",
+			rule:    "FREQ=WEEKLY;BYDAY=SU",
+			dtstart: time.Date(2026, 3, 22, 2, 30, 0, 0, berlin),
+			n:       3,
+			want:    []string{"2026-03-22T02:30:00+01:00", "2026-03-29T03:30:00+02:00", "2026-04-05T02:30:00+02:00"},
+		},
+		{
+			name:    "monthly over southern hemisphere change",
+			rule:    "FREQ=MONTHLY;BYDAY=1SU",
+			dtstart: time.Date(2026, 9, 6, 2, 30, 0, 0, sydney),
+			n:       2,
+			want:    []string{"2026-09-06T02:30:00+10:00", "2026-10-04T03:30:00+11:00"},
+		},
+		{
+			name:    "half hour gap",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 10, 3, 2, 15, 0, 0, lordHowe),
+			n:       2,
+			want:    []string{"2026-10-03T02:15:00+10:30", "2026-10-04T02:45:00+11:00"},
+		},
+		{
+			name:    "half hour overlap uses the first occurrence",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2026, 4, 4, 1, 45, 0, 0, lordHowe),
+			n:       2,
+			want:    []string{"2026-04-04T01:45:00+11:00", "2026-04-05T01:45:00+11:00"},
+		},
+		{
+			name:    "local until follows the series zone",
+			rule:    "FREQ=DAILY;UNTIL=20260309T090000",
+			dtstart: time.Date(2026, 3, 7, 9, 0, 0, 0, newYork),
+			n:       10,
+			want:    []string{"2026-03-07T09:00:00-05:00", "2026-03-08T09:00:00-04:00", "2026-03-09T09:00:00-04:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertTimes(t, mustParse(t, tt.rule).Occurrences(tt.dtstart, tt.n), tt.want)
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	dtstart := time.Date(2026, 3, 6, 9, 0, 0, 0, newYork)
+
+	t.Run("strictly after", func(t *testing.T) {
+		rule := mustParse(t, "FREQ=DAILY")
+
+		next, ok := rule.Next(dtstart, dtstart)
+		if !ok {
+			t.Fatal("expected a next occurrence")
+		}
+		if want := time.Date(2026, 3, 7, 9, 0, 0, 0, newYork); !next.Equal(want) {
+			t.Errorf("Next() = %s, want %s", next, want)
+		}
+	})
+
+	t.Run("across spring forward from utc", func(t *testing.T) {
+		rule := mustParse(t, "FREQ=DAILY")
+
+		// the stored occurrence comes back from the database in UTC
+		after := time.Date(2026, 3, 7, 14, 0, 0, 0, time.UTC)
+		next, ok := rule.Next(dtstart, after)
+		if !ok {
+			t.Fatal("expected a next occurrence")
+		}
+		if got, want := next.UTC().Format(time.RFC3339), "2026-03-08T13:00:00Z"; got != want {
+			t.Errorf("Next() = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("count exhausted", func(t *testing.T) {
+		rule := mustParse(t, "FREQ=DAILY;COUNT=2")
+
+		if _, ok := rule.Next(dtstart, dtstart.AddDate(0, 0, 1)); ok {
+			t.Error("expected the series to have ended")
+		}
+	})
+
+	t.Run("until passed", func(t *testing.T) {
+		rule := mustParse(t, "FREQ=WEEKLY;UNTIL=20260313T140000Z")
+
+		if _, ok := rule.Next(dtstart, dtstart.AddDate(0, 0, 7)); ok {
+			t.Error("expected the series to have ended")
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
+)
+
+// S3Config points at an S3 compatible service such as AWS S3 or a local MinIO
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	// PathStyle puts the bucket in the path instead of the host name, as most stand-ins expect
+	PathStyle bool
+}
+
+// S3Storage keeps objects in a bucket of an S3 compatible service
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3Storage(config *S3Config) (*S3Storage, error) {
+	lookup := minio.BucketLookupDNS
+	if config.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
+		Secure:       config.UseSSL,
+		Region:       config.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Storage{client: client, bucket: config.Bucket}, nil
+}
+
+// EnsureBucket creates the bucket when it does not exist yet
+func (s *S3Storage) EnsureBucket(ctx context.Context, region string) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil || exists {
+		return err
+	}
+	return s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{Region: region})
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	// GetObject is lazy, stat first so a missing object is reported here rather than on the first read
+	if _, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{}); err != nil {
+		return nil, s.translate(err)
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.translate(err)
+	}
+	return object, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := s.translate(s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})); err != ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+func (s *S3Storage) translate(err error) error {
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
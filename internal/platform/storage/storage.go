@@ -0,0 +1,23 @@
+// Package storage keeps uploaded files in a backend that can be swapped through configuration.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	ErrNotFound   = errors.New("storage: object not found")
+	ErrInvalidKey = errors.New("storage: invalid key")
+)
+
+// Storage stores objects under slash separated keys such as todos/<todo id>/<attachment id>
+type Storage interface {
+	// Put writes an object of the given size, replacing any object with the same key
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens an object for reading, the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes an object, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	testStorage(t, s)
+
+	for _, key := range []string{"", "../escape", "a/../../b", "/abs", "a//b", `a\b`} {
+		if err := s.Put(context.Background(), key, bytes.NewReader(nil), 0, ""); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+// TestS3Storage runs against a local stand-in such as MinIO when S3_TEST_ENDPOINT is set, e.g.
+// S3_TEST_ENDPOINT=localhost:9000 S3_TEST_ACCESS_KEY=minioadmin S3_TEST_SECRET_KEY=minioadmin
+func TestS3Storage(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT is not set")
+	}
+
+	s, err := NewS3Storage(&S3Config{
+		Endpoint:  endpoint,
+		Bucket:    "storage-test",
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Storage() error = %v", err)
+	}
+	if err := s.EnsureBucket(context.Background(), ""); err != nil {
+		t.Fatalf("EnsureBucket() error = %v", err)
+	}
+	testStorage(t, s)
+}
+
+func testStorage(t *testing.T, s Storage) {
+	ctx := context.Background()
+	key := "todos/1/2"
+	content := []byte("%PDF-1.4 attachment")
+
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() before Put error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	body, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("Get() = %q, %v, want %q", got, err, content)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() of a missing object error = %v", err)
+	}
+}
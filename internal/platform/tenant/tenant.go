@@ -0,0 +1,38 @@
+// Package tenant carries the active workspace of a request, which the repositories use to keep every
+// query inside that workspace.
+package tenant
+
+import "context"
+
+// Header selects the active workspace of a request, it takes precedence over the token's claim
+const Header = "X-Workspace-ID"
+
+type contextKey struct{}
+
+type allWorkspacesKey struct{}
+
+// Workspace is the workspace a request works in and the caller's role in it
+type Workspace struct {
+	ID   string
+	Role string
+}
+
+func WithWorkspace(ctx context.Context, workspace Workspace) context.Context {
+	return context.WithValue(ctx, contextKey{}, workspace)
+}
+
+func FromContext(ctx context.Context) (Workspace, bool) {
+	workspace, ok := ctx.Value(contextKey{}).(Workspace)
+	return workspace, ok && workspace.ID != ""
+}
+
+// WithAllWorkspaces lifts the isolation for jobs that maintain every workspace, such as purging the trash.
+// It must never be used for a context derived from a request.
+func WithAllWorkspaces(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allWorkspacesKey{}, true)
+}
+
+func AllWorkspaces(ctx context.Context) bool {
+	all, _ := ctx.Value(allWorkspacesKey{}).(bool)
+	return all
+}
@@ -0,0 +1,133 @@
+// Package tsquery turns search box input into a PostgreSQL to_tsquery expression.
+package tsquery
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrEmptyQuery = errors.New("tsquery: query has no searchable terms")
+
+// Parse converts user input to to_tsquery syntax. Words must all match, "quoted phrases" must match
+// in order, a trailing * matches word prefixes, a leading - excludes a term and OR between two terms
+// matches either of them. OR binds tighter than the implied AND, so a b OR c needs a and one of b or c.
+// Anything that is not a letter or a digit only separates words, so the result is always a well formed query.
+func Parse(input string) (string, error) {
+	// Every group has to match, one term of a group is enough
+	var groups [][]string
+	or := false
+
+	for _, token := range tokenize(input) {
+		if token.text == "OR" && !token.quoted {
+			or = len(groups) > 0
+			continue
+		}
+
+		term := token.term()
+		if term == "" {
+			continue
+		}
+
+		if or {
+			groups[len(groups)-1] = append(groups[len(groups)-1], term)
+		} else {
+			groups = append(groups, []string{term})
+		}
+		or = false
+	}
+
+	if len(groups) == 0 {
+		return "", ErrEmptyQuery
+	}
+
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		// to_tsquery gives & precedence over |, the parentheses keep the alternatives together
+		if len(group) > 1 {
+			parts[i] = "(" + strings.Join(group, " | ") + ")"
+		} else {
+			parts[i] = group[0]
+		}
+	}
+
+	return strings.Join(parts, " & "), nil
+}
+
+type token struct {
+	text    string
+	quoted  bool
+	negated bool
+	prefix  bool
+}
+
+func tokenize(input string) []token {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		switch {
+		case unicode.IsSpace(runes[i]):
+			i++
+		case runes[i] == '"' || (runes[i] == '-' && i+1 < len(runes) && runes[i+1] == '"'):
+			t := token{quoted: true, negated: runes[i] == '-'}
+			if t.negated {
+				i++
+			}
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			t.text = string(runes[i+1 : min(end, len(runes))])
+			tokens = append(tokens, t)
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+				end++
+			}
+			text := string(runes[i:end])
+			t := token{}
+			if strings.HasPrefix(text, "-") {
+				t.negated = true
+				text = text[1:]
+			}
+			if strings.HasSuffix(text, "*") {
+				t.prefix = true
+				text = strings.TrimRight(text, "*")
+			}
+			t.text = text
+			tokens = append(tokens, t)
+			i = end
+		}
+	}
+
+	return tokens
+}
+
+// term renders a token, several words in one token have to follow each other
+func (t token) term() string {
+	words := strings.FieldsFunc(t.text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	term := strings.Join(words, " <-> ")
+	if t.prefix && !t.quoted {
+		term += ":*"
+	}
+
+	if t.negated {
+		if len(words) > 1 {
+			return "!(" + term + ")"
+		}
+		return "!" + term
+	}
+
+	if len(words) > 1 {
+		return "(" + term + ")"
+	}
+	return term
+}
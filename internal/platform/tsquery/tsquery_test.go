@@ -0,0 +1,53 @@
+package tsquery
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"words", "buy milk", "buy & milk"},
+		{"phrase", `"buy milk" today`, "(buy <-> milk) & today"},
+		{"prefix", "mil* today", "mil:* & today"},
+		{"quoted prefix", `"mil*"`, "mil"},
+		{"negation", "milk -bread", "milk & !bread"},
+		{"negated phrase", `milk -"white bread"`, "milk & !(white <-> bread)"},
+		{"or", "milk OR bread", "(milk | bread)"},
+		{"or binds tighter than and", "buy milk OR bread", "buy & (milk | bread)"},
+		{"or chain", "a OR b OR c d", "(a | b | c) & d"},
+		{"lowercase or is a word", "milk or bread", "milk & or & bread"},
+		{"quoted or is a word", `milk "OR" bread`, "milk & OR & bread"},
+		{"leading or", "OR milk", "milk"},
+		{"trailing or", "milk OR", "milk"},
+		{"double or", "milk OR OR bread", "(milk | bread)"},
+		{"punctuation splits words", "e-mail's", "(e <-> mail <-> s)"},
+		{"punctuation between terms", "milk ; bread", "milk & bread"},
+		{"unterminated quote", `milk "white bread`, "milk & (white <-> bread)"},
+		{"unicode", "café niño", "café & niño"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	for _, input := range []string{"", "   ", "!@#$ %^&*", `""`, "OR", "- * ::", `-"`} {
+		if got, err := Parse(input); !errors.Is(err, ErrEmptyQuery) {
+			t.Errorf("Parse(%q) = %q, %v, want ErrEmptyQuery", input, got, err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package activity
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ActivityRepository interface {
+	repositories.Repository[entity.TodoActivity]
+	GetByID(db *gorm.DB, activity *entity.TodoActivity, todoID, id string) error
+	GetByTodoID(db *gorm.DB, activities *[]entity.TodoActivity, todoID string, page, size int) (int64, error)
+}
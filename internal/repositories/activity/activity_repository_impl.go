@@ -0,0 +1,41 @@
+package activity
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ActivityRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.TodoActivity]
+	Log *logrus.Logger
+}
+
+func NewActivityRepository(db *gorm.DB, log *logrus.Logger) *ActivityRepositoryImpl {
+	return &ActivityRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.TodoActivity]{DB: db},
+		Log:            log,
+	}
+}
+
+func (r *ActivityRepositoryImpl) GetByID(db *gorm.DB, activity *entity.TodoActivity, todoID, id string) error {
+	return db.Where("id = ? AND todo_id = ?", id, todoID).Take(&activity).Error
+}
+
+// GetByTodoID lists the history of a todo newest first
+func (r *ActivityRepositoryImpl) GetByTodoID(db *gorm.DB, activities *[]entity.TodoActivity, todoID string, page, size int) (int64, error) {
+	query := db.Model(&entity.TodoActivity{}).Where("todo_id = ?", todoID)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return 0, err
+	}
+
+	offset := (page - 1) * size
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(size).Find(activities).Error; err != nil {
+		return 0, err
+	}
+
+	return totalCount, nil
+}
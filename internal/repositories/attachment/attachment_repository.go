@@ -0,0 +1,17 @@
+package attachment
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AttachmentRepository interface {
+	repositories.Repository[entity.Attachment]
+	GetByID(db *gorm.DB, attachment *entity.Attachment, todoID, id string) error
+	GetByTodoID(db *gorm.DB, attachments *[]entity.Attachment, todoID string) error
+	Purge(db *gorm.DB, attachment *entity.Attachment) error
+	GetStorageKeysByTodoID(db *gorm.DB, todoID string) ([]string, error)
+	GetStorageKeysDeletedBefore(db *gorm.DB, before time.Time) ([]string, error)
+}
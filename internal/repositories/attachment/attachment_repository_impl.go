@@ -0,0 +1,67 @@
+package attachment
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AttachmentRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.Attachment]
+	Log *logrus.Logger
+}
+
+func NewAttachmentRepository(db *gorm.DB, log *logrus.Logger) *AttachmentRepositoryImpl {
+	return &AttachmentRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.Attachment]{DB: db},
+		Log:            log,
+	}
+}
+
+func (r *AttachmentRepositoryImpl) GetByID(db *gorm.DB, attachment *entity.Attachment, todoID, id string) error {
+	return db.Where("id = ? AND todo_id = ?", id, todoID).Take(&attachment).Error
+}
+
+func (r *AttachmentRepositoryImpl) GetByTodoID(db *gorm.DB, attachments *[]entity.Attachment, todoID string) error {
+	return db.Where("todo_id = ?", todoID).Order("created_at ASC").Find(attachments).Error
+}
+
+// Purge hard-deletes an attachment, its file has to be removed from storage separately
+func (r *AttachmentRepositoryImpl) Purge(db *gorm.DB, attachment *entity.Attachment) error {
+	return db.Unscoped().Delete(attachment).Error
+}
+
+// GetStorageKeysByTodoID returns the files attached to the todo and every subtask below it, trashed or not,
+// which are the rows a purge of the todo takes down
+func (r *AttachmentRepositoryImpl) GetStorageKeysByTodoID(db *gorm.DB, todoID string) ([]string, error) {
+	return r.storageKeys(db, "id = ?", todoID)
+}
+
+// GetStorageKeysDeletedBefore returns the files a purge of the todos trashed before the given time takes down
+func (r *AttachmentRepositoryImpl) GetStorageKeysDeletedBefore(db *gorm.DB, before time.Time) ([]string, error) {
+	return r.storageKeys(db, "deleted_at IS NOT NULL AND deleted_at < ?", before)
+}
+
+// storageKeys returns the files attached to the todos that match the condition and every subtask below them. The
+// walk is raw SQL, so it keeps to the active workspace itself.
+func (r *AttachmentRepositoryImpl) storageKeys(db *gorm.DB, condition string, args ...interface{}) ([]string, error) {
+	anchor, err := repositories.TenantCondition(db, "workspace_id")
+	if err != nil {
+		return nil, err
+	}
+	step, err := repositories.TenantCondition(db, "t.workspace_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	err = db.Raw(`WITH RECURSIVE tree AS (
+			SELECT id FROM todos WHERE ? AND ?
+			UNION
+			SELECT t.id FROM todos t JOIN tree ON t.parent_id = tree.id WHERE ?
+		) SELECT storage_key FROM attachments WHERE todo_id IN (SELECT id FROM tree)`,
+		gorm.Expr(condition, args...), anchor, step).Scan(&keys).Error
+	return keys, err
+}
@@ -0,0 +1,13 @@
+package comment
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	repositories.Repository[entity.Comment]
+	GetByID(db *gorm.DB, comment *entity.Comment, todoID, id string) error
+	GetByTodoID(db *gorm.DB, comments *[]entity.Comment, todoID string, page, size int) (int64, error)
+}
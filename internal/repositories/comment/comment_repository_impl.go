@@ -0,0 +1,41 @@
+package comment
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CommentRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.Comment]
+	Log *logrus.Logger
+}
+
+func NewCommentRepository(db *gorm.DB, log *logrus.Logger) *CommentRepositoryImpl {
+	return &CommentRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.Comment]{DB: db},
+		Log:            log,
+	}
+}
+
+func (r *CommentRepositoryImpl) GetByID(db *gorm.DB, comment *entity.Comment, todoID, id string) error {
+	return db.Where("id = ? AND todo_id = ?", id, todoID).Take(&comment).Error
+}
+
+// GetByTodoID lists the comments of a todo oldest first, so a thread reads top to bottom
+func (r *CommentRepositoryImpl) GetByTodoID(db *gorm.DB, comments *[]entity.Comment, todoID string, page, size int) (int64, error) {
+	query := db.Model(&entity.Comment{}).Where("todo_id = ?", todoID)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		return 0, err
+	}
+
+	offset := (page - 1) * size
+	if err := query.Order("created_at ASC, id ASC").Offset(offset).Limit(size).Find(comments).Error; err != nil {
+		return 0, err
+	}
+
+	return totalCount, nil
+}
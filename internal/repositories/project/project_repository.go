@@ -0,0 +1,14 @@
+package project
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ProjectRepository interface {
+	repositories.Repository[entity.Project]
+	GetByID(db *gorm.DB, project *entity.Project, id string) error
+	GetAll(db *gorm.DB, projects *[]entity.Project, archived *bool) error
+	GetByUserID(db *gorm.DB, projects *[]entity.Project, userID string, archived *bool) error
+}
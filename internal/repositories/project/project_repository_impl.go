@@ -0,0 +1,42 @@
+package project
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ProjectRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.Project]
+	Log *logrus.Logger
+}
+
+func NewProjectRepository(db *gorm.DB, log *logrus.Logger) *ProjectRepositoryImpl {
+	return &ProjectRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.Project]{DB: db},
+		Log:            log,
+	}
+}
+
+func (r *ProjectRepositoryImpl) GetByID(db *gorm.DB, project *entity.Project, id string) error {
+	return db.Where("id = ?", id).Take(&project).Error
+}
+
+// GetAll lists every project of the active workspace
+func (r *ProjectRepositoryImpl) GetAll(db *gorm.DB, projects *[]entity.Project, archived *bool) error {
+	query := db
+	if archived != nil {
+		query = query.Where("archived = ?", *archived)
+	}
+	return query.Order("name ASC").Find(projects).Error
+}
+
+// GetByUserID lists the user's projects together with the projects shared with them
+func (r *ProjectRepositoryImpl) GetByUserID(db *gorm.DB, projects *[]entity.Project, userID string, archived *bool) error {
+	query := db.Where("user_id = ? OR id IN (SELECT resource_id FROM shares WHERE resource_type = 'project' AND user_id = ? AND deleted_at IS NULL)", userID, userID)
+	if archived != nil {
+		query = query.Where("archived = ?", *archived)
+	}
+	return query.Order("name ASC").Find(projects).Error
+}
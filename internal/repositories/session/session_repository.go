@@ -0,0 +1,15 @@
+package session
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	repositories.Repository[entity.Session]
+	GetByIDForUpdate(db *gorm.DB, session *entity.Session, id string) error
+	GetActiveByUserID(db *gorm.DB, sessions *[]entity.Session, userID string) error
+	GetActiveByID(db *gorm.DB, session *entity.Session, userID, id string) error
+	RevokeByUserID(db *gorm.DB, userID string) error
+}
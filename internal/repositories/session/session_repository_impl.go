@@ -0,0 +1,46 @@
+package session
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type SessionRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.Session]
+	Log *logrus.Logger
+}
+
+func NewSessionRepository(db *gorm.DB, log *logrus.Logger) *SessionRepositoryImpl {
+	return &SessionRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.Session]{DB: db},
+		Log:            log,
+	}
+}
+
+// GetByIDForUpdate locks a session, so two refreshes with the same token cannot both rotate it
+func (r *SessionRepositoryImpl) GetByIDForUpdate(db *gorm.DB, session *entity.Session, id string) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&session).Error
+}
+
+// GetActiveByUserID lists the sessions of a user that are neither revoked nor expired, most recently used first
+func (r *SessionRepositoryImpl) GetActiveByUserID(db *gorm.DB, sessions *[]entity.Session, userID string) error {
+	return db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(sessions).Error
+}
+
+func (r *SessionRepositoryImpl) GetActiveByID(db *gorm.DB, session *entity.Session, userID, id string) error {
+	return db.Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", id, userID, time.Now()).
+		Take(&session).Error
+}
+
+// RevokeByUserID revokes every session of a user that is still open
+func (r *SessionRepositoryImpl) RevokeByUserID(db *gorm.DB, userID string) error {
+	return db.Model(&entity.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
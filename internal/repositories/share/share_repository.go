@@ -0,0 +1,18 @@
+package share
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ShareRepository interface {
+	repositories.Repository[entity.Share]
+	GetByID(db *gorm.DB, share *entity.Share, resourceType, resourceID, id string) error
+	GetByUser(db *gorm.DB, share *entity.Share, resourceType, resourceID, userID string) error
+	GetByResource(db *gorm.DB, shares *[]entity.Share, resourceType, resourceID string) error
+	Purge(db *gorm.DB, share *entity.Share) error
+	PurgeInWorkspace(db *gorm.DB, userID, workspaceID string) error
+	GetTodoRole(db *gorm.DB, userID, todoID string) (string, error)
+	GetProjectRole(db *gorm.DB, userID, projectID string) (string, error)
+}
@@ -0,0 +1,90 @@
+package share
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ShareRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.Share]
+	Log *logrus.Logger
+}
+
+func NewShareRepository(db *gorm.DB, log *logrus.Logger) *ShareRepositoryImpl {
+	return &ShareRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.Share]{DB: db},
+		Log:            log,
+	}
+}
+
+func (r *ShareRepositoryImpl) GetByID(db *gorm.DB, share *entity.Share, resourceType, resourceID, id string) error {
+	return db.Preload("User").Where("id = ? AND resource_type = ? AND resource_id = ?", id, resourceType, resourceID).Take(&share).Error
+}
+
+func (r *ShareRepositoryImpl) GetByUser(db *gorm.DB, share *entity.Share, resourceType, resourceID, userID string) error {
+	return db.Where("resource_type = ? AND resource_id = ? AND user_id = ?", resourceType, resourceID, userID).Take(&share).Error
+}
+
+func (r *ShareRepositoryImpl) GetByResource(db *gorm.DB, shares *[]entity.Share, resourceType, resourceID string) error {
+	return db.Preload("User").Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).Order("created_at ASC").Find(shares).Error
+}
+
+// Purge hard-deletes a share, a revoked grant has nothing left to restore
+func (r *ShareRepositoryImpl) Purge(db *gorm.DB, share *entity.Share) error {
+	return db.Unscoped().Delete(share).Error
+}
+
+// GetTodoRole returns the strongest role shared with the user on the todo, its parent todos or their projects,
+// so a grant on a todo or project also covers every subtask below it. It is empty without any grant.
+func (r *ShareRepositoryImpl) GetTodoRole(db *gorm.DB, userID, todoID string) (string, error) {
+	anchor, err := repositories.TenantCondition(db, "workspace_id")
+	if err != nil {
+		return "", err
+	}
+	step, err := repositories.TenantCondition(db, "t.workspace_id")
+	if err != nil {
+		return "", err
+	}
+
+	var roles []string
+	err = db.Raw(`WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, project_id FROM todos WHERE id = ? AND ?
+			UNION
+			SELECT t.id, t.parent_id, t.project_id FROM todos t JOIN ancestors a ON t.id = a.parent_id WHERE ?
+		) SELECT role FROM shares WHERE user_id = ? AND deleted_at IS NULL AND (
+			(resource_type = ? AND resource_id IN (SELECT id FROM ancestors)) OR
+			(resource_type = ? AND resource_id IN (SELECT project_id FROM ancestors WHERE project_id IS NOT NULL))
+		)`, todoID, anchor, step, userID, model.ShareResourceTodo, model.ShareResourceProject).Scan(&roles).Error
+	return strongestRole(roles), err
+}
+
+// GetProjectRole returns the role shared with the user on the project, empty without a grant
+func (r *ShareRepositoryImpl) GetProjectRole(db *gorm.DB, userID, projectID string) (string, error) {
+	var roles []string
+	err := db.Model(&entity.Share{}).
+		Where("resource_type = ? AND resource_id = ? AND user_id = ?", model.ShareResourceProject, projectID, userID).
+		Pluck("role", &roles).Error
+	return strongestRole(roles), err
+}
+
+// PurgeInWorkspace hard-deletes the shares a user holds on the todos and projects of a workspace
+func (r *ShareRepositoryImpl) PurgeInWorkspace(db *gorm.DB, userID, workspaceID string) error {
+	return db.Unscoped().
+		Where("user_id = ?", userID).
+		Where("(resource_type = ? AND resource_id IN (SELECT id FROM todos WHERE workspace_id = ?)) OR (resource_type = ? AND resource_id IN (SELECT id FROM projects WHERE workspace_id = ?))",
+			model.ShareResourceTodo, workspaceID, model.ShareResourceProject, workspaceID).
+		Delete(&entity.Share{}).Error
+}
+
+func strongestRole(roles []string) string {
+	var strongest string
+	for _, role := range roles {
+		if strongest == "" || model.ShareRoleIncludes(role, strongest) {
+			strongest = role
+		}
+	}
+	return strongest
+}
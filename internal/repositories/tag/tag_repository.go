@@ -0,0 +1,16 @@
+package tag
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type TagRepository interface {
+	repositories.Repository[entity.Tag]
+	GetByID(db *gorm.DB, tag *entity.Tag, id string) error
+	GetByName(db *gorm.DB, tag *entity.Tag, userID, name string) error
+	GetByNames(db *gorm.DB, tags *[]entity.Tag, userID string, names []string) error
+	GetByUserID(db *gorm.DB, tags *[]entity.Tag, userID string) error
+	GetTaggedTodos(db *gorm.DB, todos *[]entity.Todo, tagID string) error
+}
@@ -0,0 +1,50 @@
+package tag
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type TagRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.Tag]
+	Log *logrus.Logger
+}
+
+func NewTagRepository(db *gorm.DB, log *logrus.Logger) *TagRepositoryImpl {
+	return &TagRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.Tag]{DB: db},
+		Log:            log,
+	}
+}
+
+func (r *TagRepositoryImpl) GetByID(db *gorm.DB, tag *entity.Tag, id string) error {
+	return db.Where("id = ?", id).Take(&tag).Error
+}
+
+func (r *TagRepositoryImpl) GetByName(db *gorm.DB, tag *entity.Tag, userID, name string) error {
+	return db.Where("user_id = ? AND name = ?", userID, name).Take(&tag).Error
+}
+
+func (r *TagRepositoryImpl) GetByNames(db *gorm.DB, tags *[]entity.Tag, userID string, names []string) error {
+	return db.Where("user_id = ? AND name IN ?", userID, names).Find(tags).Error
+}
+
+func (r *TagRepositoryImpl) GetByUserID(db *gorm.DB, tags *[]entity.Tag, userID string) error {
+	return db.Where("user_id = ?", userID).Order("name ASC").Find(tags).Error
+}
+
+// GetTaggedTodos finds the ids and workspaces of the todos carrying a tag. Tags belong to a user rather than a
+// workspace, so the todos are looked up in every workspace of the owner.
+func (r *TagRepositoryImpl) GetTaggedTodos(db *gorm.DB, todos *[]entity.Todo, tagID string) error {
+	return db.Raw("SELECT todos.id, todos.workspace_id FROM todos JOIN todo_tags ON todo_tags.todo_id = todos.id WHERE todo_tags.tag_id = ?", tagID).Scan(todos).Error
+}
+
+// Delete soft-deletes the tag and detaches it from every todo
+func (r *TagRepositoryImpl) Delete(db *gorm.DB, tag *entity.Tag) error {
+	if err := db.Exec("DELETE FROM todo_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+		return err
+	}
+	return db.Delete(&tag).Error
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"reflect"
+)
+
+// workspaceColumn is the column that ties a scoped row to its workspace
+const workspaceColumn = "workspace_id"
+
+var (
+	ErrMissingWorkspace  = errors.New("repository: no active workspace")
+	ErrWorkspaceMismatch = errors.New("repository: row belongs to another workspace")
+)
+
+// RegisterTenantScope confines every query, update and delete of the given models to the workspace active in
+// the statement's context, and stamps created rows with it. A statement without a workspace fails instead of
+// running unscoped, so a repository cannot leak rows across workspaces by forgetting a condition.
+func RegisterTenantScope(db *gorm.DB, models ...interface{}) error {
+	tables := make(map[string]bool, len(models))
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return err
+		}
+		tables[stmt.Schema.Table] = true
+	}
+
+	scope := &tenantScope{tables: tables}
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenant:create", scope.create); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:query", scope.where); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenant:row", scope.where); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:update", scope.where); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("tenant:delete", scope.where)
+}
+
+type tenantScope struct {
+	tables map[string]bool
+}
+
+// workspace returns the workspace to scope the statement to, ok is false when it is not scoped
+func (s *tenantScope) workspace(db *gorm.DB) (string, bool) {
+	if db.Statement.Schema == nil || !s.tables[db.Statement.Schema.Table] || db.Statement.Context == nil {
+		return "", false
+	}
+	if tenant.AllWorkspaces(db.Statement.Context) {
+		return "", false
+	}
+
+	workspace, ok := tenant.FromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrMissingWorkspace)
+		return "", false
+	}
+	return workspace.ID, true
+}
+
+func (s *tenantScope) where(db *gorm.DB) {
+	workspaceID, ok := s.workspace(db)
+	if !ok {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: workspaceColumn}, Value: workspaceID},
+	}})
+}
+
+func (s *tenantScope) create(db *gorm.DB) {
+	workspaceID, ok := s.workspace(db)
+	if !ok {
+		return
+	}
+
+	field := db.Statement.Schema.LookUpField(workspaceColumn)
+	if field == nil {
+		return
+	}
+
+	stamp := func(rv reflect.Value) {
+		value, zero := field.ValueOf(db.Statement.Context, rv)
+		if !zero && value != workspaceID {
+			_ = db.AddError(ErrWorkspaceMismatch)
+			return
+		}
+		if err := field.Set(db.Statement.Context, rv, workspaceID); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+
+	rv := reflect.Indirect(db.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			stamp(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		stamp(rv)
+	}
+}
+
+// TenantCondition scopes raw SQL, which the callbacks never see, to the workspace active in the context of db.
+// column is the workspace column as the query names it. A context that spans all workspaces is not narrowed down,
+// one without a workspace fails like a scoped statement does.
+func TenantCondition(db *gorm.DB, column string) (clause.Expr, error) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		return clause.Expr{}, ErrMissingWorkspace
+	}
+	if tenant.AllWorkspaces(ctx) {
+		return gorm.Expr("TRUE"), nil
+	}
+
+	workspace, ok := tenant.FromContext(ctx)
+	if !ok {
+		return clause.Expr{}, ErrMissingWorkspace
+	}
+	return gorm.Expr(column+" = ?", workspace.ID), nil
+}
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"strings"
+	"testing"
+)
+
+func newScopedDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	if err := RegisterTenantScope(db, &entity.Todo{}, &entity.Project{}); err != nil {
+		t.Fatalf("RegisterTenantScope() error = %v", err)
+	}
+	return db
+}
+
+func TestTenantScopeFiltersStatements(t *testing.T) {
+	db := newScopedDB(t)
+	ctx := tenant.WithWorkspace(context.Background(), tenant.Workspace{ID: "ws-1", Role: "member"})
+
+	tests := []struct {
+		name string
+		run  func(db *gorm.DB) *gorm.DB
+	}{
+		{"find", func(db *gorm.DB) *gorm.DB { return db.Where("done = ?", false).Find(&[]entity.Todo{}) }},
+		{"take unscoped", func(db *gorm.DB) *gorm.DB { return db.Unscoped().Where("id = ?", "t-1").Take(&entity.Todo{}) }},
+		{"count", func(db *gorm.DB) *gorm.DB { var n int64; return db.Model(&entity.Project{}).Count(&n) }},
+		{"update", func(db *gorm.DB) *gorm.DB {
+			return db.Model(&entity.Todo{}).Where("id = ?", "t-1").Update("done", true)
+		}},
+		{"delete", func(db *gorm.DB) *gorm.DB { return db.Where("id = ?", "p-1").Delete(&entity.Project{}) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := tt.run(db.WithContext(ctx))
+			if tx.Error != nil {
+				t.Fatalf("error = %v", tx.Error)
+			}
+			if sql := tx.Statement.SQL.String(); !strings.Contains(sql, `."workspace_id" = $`) {
+				t.Errorf("SQL = %s, want a workspace condition", sql)
+			}
+			if !containsVar(tx.Statement.Vars, "ws-1") {
+				t.Errorf("Vars = %v, want the workspace", tx.Statement.Vars)
+			}
+		})
+	}
+}
+
+func TestTenantScopeIgnoresOtherTables(t *testing.T) {
+	db := newScopedDB(t)
+
+	tx := db.WithContext(context.Background()).Where("id = ?", "u-1").Take(&entity.User{})
+	if tx.Error != nil {
+		t.Fatalf("error = %v", tx.Error)
+	}
+	if sql := tx.Statement.SQL.String(); strings.Contains(sql, "workspace_id") {
+		t.Errorf("SQL = %s, want no workspace condition", sql)
+	}
+}
+
+func TestTenantScopeFailsWithoutWorkspace(t *testing.T) {
+	db := newScopedDB(t)
+
+	if err := db.WithContext(context.Background()).Find(&[]entity.Todo{}).Error; !errors.Is(err, ErrMissingWorkspace) {
+		t.Errorf("Find() error = %v, want ErrMissingWorkspace", err)
+	}
+
+	ctx := tenant.WithAllWorkspaces(context.Background())
+	tx := db.WithContext(ctx).Find(&[]entity.Todo{})
+	if tx.Error != nil {
+		t.Fatalf("Find() with all workspaces error = %v", tx.Error)
+	}
+	if sql := tx.Statement.SQL.String(); strings.Contains(sql, "workspace_id") {
+		t.Errorf("SQL = %s, want no workspace condition", sql)
+	}
+}
+
+func TestTenantScopeStampsCreatedRows(t *testing.T) {
+	db := newScopedDB(t)
+	ctx := tenant.WithWorkspace(context.Background(), tenant.Workspace{ID: "ws-1"})
+
+	todo := &entity.Todo{ID: "t-1", Title: "a"}
+	if err := db.WithContext(ctx).Create(todo).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if todo.WorkspaceID != "ws-1" {
+		t.Errorf("WorkspaceID = %q, want ws-1", todo.WorkspaceID)
+	}
+
+	projects := []entity.Project{{ID: "p-1", Name: "a"}, {ID: "p-2", Name: "b", WorkspaceID: "ws-1"}}
+	if err := db.WithContext(ctx).Create(&projects).Error; err != nil {
+		t.Fatalf("Create() batch error = %v", err)
+	}
+	if projects[0].WorkspaceID != "ws-1" {
+		t.Errorf("WorkspaceID = %q, want ws-1", projects[0].WorkspaceID)
+	}
+
+	other := &entity.Todo{ID: "t-2", Title: "b", WorkspaceID: "ws-2"}
+	if err := db.WithContext(ctx).Create(other).Error; !errors.Is(err, ErrWorkspaceMismatch) {
+		t.Errorf("Create() error = %v, want ErrWorkspaceMismatch", err)
+	}
+}
+
+func TestTenantConditionScopesRawSQL(t *testing.T) {
+	db := newScopedDB(t)
+	ctx := tenant.WithWorkspace(context.Background(), tenant.Workspace{ID: "ws-1"})
+
+	scoped := db.WithContext(ctx)
+	anchor, err := TenantCondition(scoped, "workspace_id")
+	if err != nil {
+		t.Fatalf("TenantCondition() error = %v", err)
+	}
+	step, err := TenantCondition(scoped, "t.workspace_id")
+	if err != nil {
+		t.Fatalf("TenantCondition() error = %v", err)
+	}
+
+	// Raw renders the statement right away, dry run mode only refuses to scan it
+	tx := scoped.Raw(`WITH RECURSIVE tree AS (
+			SELECT id FROM todos WHERE id = ? AND ?
+			UNION
+			SELECT t.id FROM todos t JOIN tree ON t.parent_id = tree.id WHERE ?
+		) SELECT id FROM tree`, "t-1", anchor, step)
+	if tx.Error != nil {
+		t.Fatalf("Raw() error = %v", tx.Error)
+	}
+	sql := tx.Statement.SQL.String()
+	if !strings.Contains(sql, "WHERE id = $1 AND workspace_id = $2") || !strings.Contains(sql, "WHERE t.workspace_id = $3") {
+		t.Errorf("SQL = %s, want the workspace on both sides of the walk", sql)
+	}
+	if len(tx.Statement.Vars) != 3 || tx.Statement.Vars[1] != "ws-1" || tx.Statement.Vars[2] != "ws-1" {
+		t.Errorf("Vars = %v, want the workspace twice", tx.Statement.Vars)
+	}
+}
+
+func TestTenantConditionWithoutWorkspace(t *testing.T) {
+	db := newScopedDB(t)
+
+	if _, err := TenantCondition(db.WithContext(context.Background()), "workspace_id"); !errors.Is(err, ErrMissingWorkspace) {
+		t.Errorf("TenantCondition() error = %v, want ErrMissingWorkspace", err)
+	}
+
+	condition, err := TenantCondition(db.WithContext(tenant.WithAllWorkspaces(context.Background())), "workspace_id")
+	if err != nil {
+		t.Fatalf("TenantCondition() with all workspaces error = %v", err)
+	}
+	if condition.SQL != "TRUE" || len(condition.Vars) != 0 {
+		t.Errorf("TenantCondition() with all workspaces = %+v, want TRUE", condition)
+	}
+}
+
+func containsVar(vars []interface{}, want interface{}) bool {
+	for _, v := range vars {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
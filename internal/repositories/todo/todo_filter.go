@@ -0,0 +1,222 @@
+package todo
+
+import (
+	"github.com/google/uuid"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/platform/filter"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type filterKind int
+
+const (
+	filterText filterKind = iota
+	filterBool
+	filterUUID
+	filterPriority
+	filterTime
+	filterTag
+)
+
+// filterField is a todo column that filter expressions may use
+type filterField struct {
+	column   string
+	kind     filterKind
+	nullable bool
+}
+
+// filterFields is the whitelist of filterable fields, anything else is rejected
+var filterFields = map[string]filterField{
+	"id":          {column: "id", kind: filterUUID},
+	"title":       {column: "title", kind: filterText},
+	"description": {column: "description", kind: filterText, nullable: true},
+	"done":        {column: "done", kind: filterBool},
+	"priority":    {column: "priority", kind: filterPriority},
+	"due_at":      {column: "due_at", kind: filterTime, nullable: true},
+	"start_at":    {column: "start_at", kind: filterTime, nullable: true},
+	"created_at":  {column: "created_at", kind: filterTime},
+	"updated_at":  {column: "updated_at", kind: filterTime},
+	"project_id":  {column: "project_id", kind: filterUUID, nullable: true},
+	"parent_id":   {column: "parent_id", kind: filterUUID, nullable: true},
+	"series_id":   {column: "series_id", kind: filterUUID, nullable: true},
+	"tag":         {kind: filterTag},
+}
+
+var filterOperators = map[filterKind][]filter.Operator{
+	filterText:     {filter.OpEqual, filter.OpNotEqual, filter.OpContains, filter.OpNotContains},
+	filterBool:     {filter.OpEqual, filter.OpNotEqual},
+	filterUUID:     {filter.OpEqual, filter.OpNotEqual},
+	filterPriority: {filter.OpEqual, filter.OpNotEqual, filter.OpGreater, filter.OpGreaterEqual, filter.OpLess, filter.OpLessEqual},
+	filterTime:     {filter.OpEqual, filter.OpNotEqual, filter.OpGreater, filter.OpGreaterEqual, filter.OpLess, filter.OpLessEqual},
+	filterTag:      {filter.OpEqual, filter.OpNotEqual},
+}
+
+var sqlOperators = map[filter.Operator]string{
+	filter.OpEqual:        "=",
+	filter.OpNotEqual:     "<>",
+	filter.OpGreater:      ">",
+	filter.OpGreaterEqual: ">=",
+	filter.OpLess:         "<",
+	filter.OpLessEqual:    "<=",
+}
+
+// taggedSelect finds the todos carrying a tag name
+const taggedSelect = "SELECT todo_tags.todo_id FROM todo_tags JOIN tags ON tags.id = todo_tags.tag_id WHERE tags.name = ? AND tags.deleted_at IS NULL"
+
+// compileFilter turns a filter expression into a condition, values are always passed as parameters
+func compileFilter(node filter.Node) (string, []interface{}, error) {
+	switch n := node.(type) {
+	case *filter.And:
+		return compileBinary(n.Left, n.Right, "AND")
+	case *filter.Or:
+		return compileBinary(n.Left, n.Right, "OR")
+	case *filter.Not:
+		condition, vars, err := compileFilter(n.Expr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + condition, vars, nil
+	case *filter.Comparison:
+		condition, vars, err := compileComparison(n)
+		if err != nil {
+			return "", nil, err
+		}
+		return "(" + condition + ")", vars, nil
+	default:
+		return "", nil, filter.ErrInvalidFilter
+	}
+}
+
+func compileBinary(left, right filter.Node, op string) (string, []interface{}, error) {
+	l, lVars, err := compileFilter(left)
+	if err != nil {
+		return "", nil, err
+	}
+	r, rVars, err := compileFilter(right)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + l + " " + op + " " + r + ")", append(lVars, rVars...), nil
+}
+
+func compileComparison(n *filter.Comparison) (string, []interface{}, error) {
+	field, ok := filterFields[n.Field]
+	if !ok {
+		return "", nil, n.Errorf("unknown field %q, filterable fields are %s", n.Field, strings.Join(filterFieldNames(), ", "))
+	}
+	if !allowsOperator(field.kind, n.Op) {
+		return "", nil, n.Errorf("operator %s cannot be used with %s", n.Op, n.Field)
+	}
+
+	if n.Value.IsNull() {
+		if !field.nullable {
+			return "", nil, n.Errorf("%s is never null", n.Field)
+		}
+		switch n.Op {
+		case filter.OpEqual:
+			return field.column + " IS NULL", nil, nil
+		case filter.OpNotEqual:
+			return field.column + " IS NOT NULL", nil, nil
+		default:
+			return "", nil, n.Errorf("null can only be compared with : or !:")
+		}
+	}
+
+	switch field.kind {
+	case filterTag:
+		if n.Op == filter.OpNotEqual {
+			return "id NOT IN (" + taggedSelect + ")", []interface{}{n.Value.Text}, nil
+		}
+		return "id IN (" + taggedSelect + ")", []interface{}{n.Value.Text}, nil
+	case filterText:
+		switch n.Op {
+		case filter.OpContains:
+			return field.column + " ILIKE ?", []interface{}{"%" + escapeLike(n.Value.Text) + "%"}, nil
+		case filter.OpNotContains:
+			return field.column + " NOT ILIKE ?", []interface{}{"%" + escapeLike(n.Value.Text) + "%"}, nil
+		}
+	case filterTime:
+		// A bare date matches the whole day when compared for equality
+		if day, err := time.Parse(time.DateOnly, n.Value.Text); err == nil {
+			switch n.Op {
+			case filter.OpEqual:
+				return field.column + " >= ? AND " + field.column + " < ?", []interface{}{day, day.AddDate(0, 0, 1)}, nil
+			case filter.OpNotEqual:
+				return "NOT (" + field.column + " >= ? AND " + field.column + " < ?)", []interface{}{day, day.AddDate(0, 0, 1)}, nil
+			}
+		}
+	}
+
+	value, err := filterValue(n, field.kind)
+	if err != nil {
+		return "", nil, err
+	}
+
+	condition := field.column + " " + sqlOperators[n.Op] + " ?"
+	// A value never equals a missing one, unequal should still match it
+	if n.Op == filter.OpNotEqual && field.nullable {
+		condition = "(" + condition + " OR " + field.column + " IS NULL)"
+	}
+	return condition, []interface{}{value}, nil
+}
+
+// filterValue checks the value against the field type so a bad value is a filter error instead of a database one
+func filterValue(n *filter.Comparison, kind filterKind) (interface{}, error) {
+	text := n.Value.Text
+	switch kind {
+	case filterBool:
+		v, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, n.Errorf("%s must be true or false", n.Field)
+		}
+		return v, nil
+	case filterUUID:
+		if _, err := uuid.Parse(text); err != nil {
+			return nil, n.Errorf("%s must be a UUID", n.Field)
+		}
+		return text, nil
+	case filterPriority:
+		for level, name := range model.TodoPriorities {
+			if strings.EqualFold(text, name) {
+				return level, nil
+			}
+		}
+		return nil, n.Errorf("%s must be one of %s", n.Field, strings.Join(model.TodoPriorities, ", "))
+	case filterTime:
+		if v, err := time.Parse(time.RFC3339, text); err == nil {
+			return v, nil
+		}
+		if v, err := time.Parse(time.DateOnly, text); err == nil {
+			return v, nil
+		}
+		return nil, n.Errorf("%s must be a date (2006-01-02) or a timestamp (RFC3339)", n.Field)
+	default:
+		return text, nil
+	}
+}
+
+func allowsOperator(kind filterKind, op filter.Operator) bool {
+	for _, allowed := range filterOperators[kind] {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+func filterFieldNames() []string {
+	names := make([]string, 0, len(filterFields))
+	for name := range filterFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// escapeLike makes the wildcards of a LIKE pattern match literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
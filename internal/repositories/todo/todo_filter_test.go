@@ -0,0 +1,115 @@
+package todo
+
+import (
+	"errors"
+	"github.com/savioruz/mikti-task/internal/platform/filter"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompileFilter(t *testing.T) {
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	id := "0b7a8f2e-3c1d-4e5f-8a9b-0c1d2e3f4a5b"
+
+	tests := []struct {
+		name      string
+		input     string
+		condition string
+		vars      []interface{}
+	}{
+		{"bool", "done:false", "(done = ?)", []interface{}{false}},
+		{"bool not equal", "done!:true", "(done <> ?)", []interface{}{true}},
+		{"text equal", `title:"weekly report"`, "(title = ?)", []interface{}{"weekly report"}},
+		{"contains", "title~report", "(title ILIKE ?)", []interface{}{"%report%"}},
+		{"not contains", "description!~draft", "(description NOT ILIKE ?)", []interface{}{"%draft%"}},
+		{"contains escapes wildcards", `title~"50%_off\\"`, "(title ILIKE ?)", []interface{}{`%50\%\_off\\%`}},
+		{"priority by name", "priority>=HIGH", "(priority >= ?)", []interface{}{3}},
+		{"uuid", "project_id:" + id, "(project_id = ?)", []interface{}{id}},
+		{"null", "due_at:null", "(due_at IS NULL)", nil},
+		{"not null", "parent_id!:null", "(parent_id IS NOT NULL)", nil},
+		{"quoted null is text", `description:"null"`, "(description = ?)", []interface{}{"null"}},
+		{"date equals the whole day", "due_at:2026-01-02", "(due_at >= ? AND due_at < ?)", []interface{}{day, day.AddDate(0, 0, 1)}},
+		{"date not equal the whole day", "created_at!:2026-01-02", "(NOT (created_at >= ? AND created_at < ?))", []interface{}{day, day.AddDate(0, 0, 1)}},
+		{"date comparison", "created_at<2026-01-02", "(created_at < ?)", []interface{}{day}},
+		{"timestamp", "updated_at>2026-01-02T00:00:00Z", "(updated_at > ?)", []interface{}{day}},
+		{"nullable not equal matches null", "project_id!:" + id, "((project_id <> ? OR project_id IS NULL))", []interface{}{id}},
+		{"not nullable not equal", "title!:a", "(title <> ?)", []interface{}{"a"}},
+		{"tag", "tag:work", "(id IN (" + taggedSelect + "))", []interface{}{"work"}},
+		{"tag not equal", "tag!:work", "(id NOT IN (" + taggedSelect + "))", []interface{}{"work"}},
+		{"and or not", "done:true AND (tag:a OR NOT tag:b)", "((done = ?) AND ((id IN (" + taggedSelect + ")) OR NOT (id IN (" + taggedSelect + "))))", []interface{}{true, "a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := filter.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			condition, vars, err := compileFilter(node)
+			if err != nil {
+				t.Fatalf("compileFilter(%q) error = %v", tt.input, err)
+			}
+			if condition != tt.condition {
+				t.Errorf("compileFilter(%q) condition = %s, want %s", tt.input, condition, tt.condition)
+			}
+			if !reflect.DeepEqual(vars, tt.vars) {
+				t.Errorf("compileFilter(%q) vars = %#v, want %#v", tt.input, vars, tt.vars)
+			}
+		})
+	}
+}
+
+func TestCompileFilterErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		msg   string
+	}{
+		{"unknown field", "user_id:x", "unknown field"},
+		{"column that is not whitelisted", "deleted_at:null", "unknown field"},
+		{"contains on uuid", "id~abc", "cannot be used"},
+		{"order on text", "title>a", "cannot be used"},
+		{"order on bool", "done<=true", "cannot be used"},
+		{"contains on tag", "tag~wo", "cannot be used"},
+		{"null on required field", "title:null", "never null"},
+		{"null ordered", "due_at>null", "null can only be compared"},
+		{"bad bool", "done:yes", "true or false"},
+		{"bad uuid", "project_id:abc", "must be a UUID"},
+		{"bad priority", "priority:extreme", "must be one of"},
+		{"bad time", "due_at>tomorrow", "must be a date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := filter.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.input, err)
+			}
+			_, _, err = compileFilter(node)
+			if !errors.Is(err, filter.ErrInvalidFilter) {
+				t.Fatalf("compileFilter(%q) error = %v, want ErrInvalidFilter", tt.input, err)
+			}
+			if !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("compileFilter(%q) error = %v, want it to mention %q", tt.input, err, tt.msg)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"plain": "plain",
+		"100%":  `100\%`,
+		"a_b":   `a\_b`,
+		`back\`: `back\\`,
+		`\%_`:   `\\\%\_`,
+		"":      "",
+	}
+	for input, want := range tests {
+		if got := escapeLike(input); got != want {
+			t.Errorf("escapeLike(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
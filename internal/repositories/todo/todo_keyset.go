@@ -0,0 +1,257 @@
+package todo
+
+import (
+	"fmt"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/platform/cursor"
+	"gorm.io/gorm/clause"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type keyKind int
+
+const (
+	keyString keyKind = iota
+	keyInt
+	keyBool
+	keyFloat
+	keyTime
+)
+
+// sortKey is one column of a list ordering, the value reads it from a todo for a cursor
+type sortKey struct {
+	expr string
+	vars []interface{}
+	desc bool
+	// nullable keys sort NULLs last in either direction
+	nullable bool
+	kind     keyKind
+	value    func(todo *entity.Todo) *string
+}
+
+// sortKeys resolves the ordering of a list, ending with the id so that every row has a unique position.
+// The signature identifies the ordering inside cursors.
+func (r *TodoRepositoryImpl) sortKeys(opts model.TodoQueryOptions) ([]sortKey, string) {
+	// Searches default to the best matches first
+	sort, order := opts.Sort, strings.ToLower(opts.Order)
+	if sort == "" || order == "" {
+		sort, order = "created_at", "desc"
+		if opts.Query != nil {
+			sort = "rank"
+		}
+	}
+	desc := order == "desc"
+
+	var keys []sortKey
+	switch sort {
+	case "id":
+	case "title":
+		keys = append(keys, sortKey{expr: "title", desc: desc, kind: keyString, value: func(t *entity.Todo) *string {
+			return &t.Title
+		}})
+	case "done":
+		keys = append(keys, sortKey{expr: "done", desc: desc, kind: keyBool, value: func(t *entity.Todo) *string {
+			v := strconv.FormatBool(t.Done)
+			return &v
+		}})
+	case "due_at":
+		// Todos without a due date always go last
+		keys = append(keys, dueAtKey(desc))
+	case "priority":
+		keys = append(keys, sortKey{expr: "priority", desc: desc, kind: keyInt, value: func(t *entity.Todo) *string {
+			v := strconv.Itoa(t.Priority)
+			return &v
+		}}, dueAtKey(false), createdAtKey(false))
+	case "position":
+		keys = append(keys, sortKey{expr: "position", desc: desc, kind: keyFloat, value: func(t *entity.Todo) *string {
+			v := strconv.FormatFloat(t.Position, 'g', -1, 64)
+			return &v
+		}}, createdAtKey(desc))
+	case "updated_at":
+		keys = append(keys, sortKey{expr: "updated_at", desc: desc, kind: keyTime, value: func(t *entity.Todo) *string {
+			return formatTime(&t.UpdatedAt)
+		}})
+	case "rank":
+		if opts.Query == nil {
+			keys = append(keys, createdAtKey(desc))
+			break
+		}
+		keys = append(keys, sortKey{
+			expr: "ts_rank(search_vector, to_tsquery(?, ?))::float8",
+			vars: []interface{}{searchConfig, *opts.Query},
+			desc: desc,
+			kind: keyFloat,
+			value: func(t *entity.Todo) *string {
+				if t.SearchRank == nil {
+					return nil
+				}
+				v := strconv.FormatFloat(*t.SearchRank, 'g', -1, 64)
+				return &v
+			},
+		}, createdAtKey(true))
+	default:
+		keys = append(keys, createdAtKey(desc))
+	}
+
+	keys = append(keys, sortKey{expr: "id", desc: desc, kind: keyString, value: func(t *entity.Todo) *string {
+		return &t.ID
+	}})
+
+	return keys, fmt.Sprintf("%s:%s", sort, order)
+}
+
+func createdAtKey(desc bool) sortKey {
+	return sortKey{expr: "created_at", desc: desc, kind: keyTime, value: func(t *entity.Todo) *string {
+		return formatTime(&t.CreatedAt)
+	}}
+}
+
+func dueAtKey(desc bool) sortKey {
+	return sortKey{expr: "due_at", desc: desc, nullable: true, kind: keyTime, value: func(t *entity.Todo) *string {
+		return formatTime(t.DueAt)
+	}}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	v := t.UTC().Format(time.RFC3339Nano)
+	return &v
+}
+
+// orderBy sorts by the keys, reversed when paging backwards
+func orderBy(keys []sortKey, reverse bool) clause.OrderBy {
+	columns := make([]string, len(keys))
+	var vars []interface{}
+	for i, key := range keys {
+		direction := "ASC"
+		if key.desc != reverse {
+			direction = "DESC"
+		}
+		columns[i] = fmt.Sprintf("%s %s", key.expr, direction)
+		if key.nullable {
+			if reverse {
+				columns[i] += " NULLS FIRST"
+			} else {
+				columns[i] += " NULLS LAST"
+			}
+		}
+		vars = append(vars, key.vars...)
+	}
+
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                strings.Join(columns, ", "),
+		Vars:               vars,
+		WithoutParentheses: true,
+	}}
+}
+
+// newCursor points at the todo in the given ordering
+func newCursor(keys []sortKey, signature string, todo *entity.Todo) *string {
+	values := make([]*string, len(keys))
+	for i, key := range keys {
+		values[i] = key.value(todo)
+	}
+	token := cursor.Cursor{Sort: signature, Values: values}.Encode()
+	return &token
+}
+
+// seek builds the condition matching the rows that come after the cursor in the key order, or before it
+// when backward is set: the keys are equal up to one that is past the cursor value.
+func seek(keys []sortKey, signature string, c *cursor.Cursor, backward bool) (string, []interface{}, error) {
+	if c.Sort != signature || len(c.Values) != len(keys) {
+		return "", nil, cursor.ErrInvalidCursor
+	}
+
+	var terms, equal []string
+	var vars, equalVars []interface{}
+	for i, key := range keys {
+		value, err := key.parse(c.Values[i])
+		if err != nil {
+			return "", nil, err
+		}
+
+		if past, pastVars, ok := key.past(value, backward); ok {
+			terms = append(terms, "("+strings.Join(append(append([]string{}, equal...), past), " AND ")+")")
+			vars = append(append(vars, equalVars...), pastVars...)
+		}
+
+		if value == nil {
+			equal = append(equal, key.expr+" IS NULL")
+			equalVars = append(equalVars, key.vars...)
+		} else {
+			equal = append(equal, key.expr+" = ?")
+			equalVars = append(append(equalVars, key.vars...), value)
+		}
+	}
+
+	return "(" + strings.Join(terms, " OR ") + ")", vars, nil
+}
+
+// past compares the key to a cursor value, ok is false when no row can be past it
+func (k sortKey) past(value interface{}, backward bool) (string, []interface{}, bool) {
+	op := ">"
+	if k.desc != backward {
+		op = "<"
+	}
+
+	if !k.nullable {
+		return fmt.Sprintf("%s %s ?", k.expr, op), append(append([]interface{}{}, k.vars...), value), true
+	}
+
+	// NULLs sort after every value, going forward they follow any value and backward they precede none
+	switch {
+	case value == nil && backward:
+		return k.expr + " IS NOT NULL", k.vars, true
+	case value == nil:
+		return "", nil, false
+	case backward:
+		return fmt.Sprintf("%s %s ?", k.expr, op), append(append([]interface{}{}, k.vars...), value), true
+	default:
+		vars := append(append(append([]interface{}{}, k.vars...), value), k.vars...)
+		return fmt.Sprintf("(%s %s ? OR %s IS NULL)", k.expr, op, k.expr), vars, true
+	}
+}
+
+// parse converts a cursor value back to the type of the key
+func (k sortKey) parse(value *string) (interface{}, error) {
+	if value == nil {
+		if !k.nullable {
+			return nil, cursor.ErrInvalidCursor
+		}
+		return nil, nil
+	}
+
+	var (
+		v   interface{}
+		err error
+	)
+	switch k.kind {
+	case keyInt:
+		// The only integer key is the smallint priority, a wider value would only fail in the driver
+		var n int64
+		n, err = strconv.ParseInt(*value, 10, 16)
+		v = int(n)
+	case keyBool:
+		v, err = strconv.ParseBool(*value)
+	case keyFloat:
+		v, err = strconv.ParseFloat(*value, 64)
+	case keyTime:
+		v, err = time.Parse(time.RFC3339Nano, *value)
+	default:
+		// PostgreSQL refuses text with a NUL byte
+		if strings.ContainsRune(*value, 0) {
+			return nil, cursor.ErrInvalidCursor
+		}
+		v = *value
+	}
+	if err != nil {
+		return nil, cursor.ErrInvalidCursor
+	}
+
+	return v, nil
+}
@@ -0,0 +1,170 @@
+package todo
+
+import (
+	"errors"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/platform/cursor"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestSeekNullableKey(t *testing.T) {
+	r := &TodoRepositoryImpl{}
+	due := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		order     string
+		due       *string
+		backward  bool
+		condition string
+		vars      []interface{}
+	}{
+		{"forward from a date", "asc", ptr("2026-01-02T03:04:05Z"), false,
+			"(((due_at > ? OR due_at IS NULL)) OR (due_at = ? AND id > ?))", []interface{}{due, due, "t-1"}},
+		{"backward from a date", "asc", ptr("2026-01-02T03:04:05Z"), true,
+			"((due_at < ?) OR (due_at = ? AND id < ?))", []interface{}{due, due, "t-1"}},
+		{"forward from no date", "asc", nil, false,
+			"((due_at IS NULL AND id > ?))", []interface{}{"t-1"}},
+		{"backward from no date", "asc", nil, true,
+			"((due_at IS NOT NULL) OR (due_at IS NULL AND id < ?))", []interface{}{"t-1"}},
+		{"descending forward from a date", "desc", ptr("2026-01-02T03:04:05Z"), false,
+			"(((due_at < ? OR due_at IS NULL)) OR (due_at = ? AND id < ?))", []interface{}{due, due, "t-1"}},
+		{"descending backward from no date", "desc", nil, true,
+			"((due_at IS NOT NULL) OR (due_at IS NULL AND id > ?))", []interface{}{"t-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, signature := r.sortKeys(model.TodoQueryOptions{Sort: "due_at", Order: tt.order})
+			c := &cursor.Cursor{Sort: signature, Values: []*string{tt.due, ptr("t-1")}}
+
+			condition, vars, err := seek(keys, signature, c, tt.backward)
+			if err != nil {
+				t.Fatalf("seek() error = %v", err)
+			}
+			if condition != tt.condition {
+				t.Errorf("seek() condition = %s, want %s", condition, tt.condition)
+			}
+			if !reflect.DeepEqual(vars, tt.vars) {
+				t.Errorf("seek() vars = %v, want %v", vars, tt.vars)
+			}
+		})
+	}
+}
+
+func TestSeekRoundTrip(t *testing.T) {
+	r := &TodoRepositoryImpl{}
+	due := time.Date(2026, 1, 2, 3, 4, 5, 6, time.FixedZone("", 3600))
+	todo := &entity.Todo{ID: "t-1", Title: "a", Priority: 3, DueAt: &due, Position: 1.5}
+	todo.CreatedAt = due
+
+	for _, sort := range []string{"id", "title", "done", "due_at", "priority", "position", "updated_at", "created_at"} {
+		t.Run(sort, func(t *testing.T) {
+			opts := model.TodoQueryOptions{Sort: sort, Order: "asc"}
+			keys, signature := r.sortKeys(opts)
+
+			c, err := cursor.Decode(*newCursor(keys, signature, todo))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if _, _, err := seek(keys, signature, c, false); err != nil {
+				t.Errorf("seek() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSeekRejectsCursor(t *testing.T) {
+	r := &TodoRepositoryImpl{}
+	keys, signature := r.sortKeys(model.TodoQueryOptions{Sort: "priority", Order: "desc"})
+	valid := func() []*string {
+		return []*string{ptr("3"), ptr("2026-01-02T03:04:05Z"), ptr("2026-01-01T00:00:00Z"), ptr("t-1")}
+	}
+
+	tests := []struct {
+		name   string
+		cursor cursor.Cursor
+	}{
+		{"other sort", cursor.Cursor{Sort: "priority:asc", Values: valid()}},
+		{"other field", cursor.Cursor{Sort: "title:desc", Values: valid()}},
+		{"too few values", cursor.Cursor{Sort: signature, Values: valid()[:3]}},
+		{"too many values", cursor.Cursor{Sort: signature, Values: append(valid(), ptr("x"))}},
+		{"not a number", cursor.Cursor{Sort: signature, Values: func() []*string { v := valid(); v[0] = ptr("high"); return v }()}},
+		{"out of range", cursor.Cursor{Sort: signature, Values: func() []*string { v := valid(); v[0] = ptr("40000"); return v }()}},
+		{"not a time", cursor.Cursor{Sort: signature, Values: func() []*string { v := valid(); v[1] = ptr("tomorrow"); return v }()}},
+		{"null required key", cursor.Cursor{Sort: signature, Values: func() []*string { v := valid(); v[2] = nil; return v }()}},
+		{"null id", cursor.Cursor{Sort: signature, Values: func() []*string { v := valid(); v[3] = nil; return v }()}},
+		{"nul byte", cursor.Cursor{Sort: signature, Values: func() []*string { v := valid(); v[3] = ptr("t\x00"); return v }()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Tokens come from clients, so they go through encoding like a tampered one would
+			c, err := cursor.Decode(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if _, _, err := seek(keys, signature, c, false); !errors.Is(err, cursor.ErrInvalidCursor) {
+				t.Errorf("seek() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestSortKeyParse(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	tests := []struct {
+		name  string
+		key   sortKey
+		value *string
+		want  interface{}
+	}{
+		{"string", sortKey{kind: keyString}, ptr("abc"), "abc"},
+		{"int", sortKey{kind: keyInt}, ptr("4"), 4},
+		{"bool", sortKey{kind: keyBool}, ptr("true"), true},
+		{"float", sortKey{kind: keyFloat}, ptr("0.25"), 0.25},
+		{"time", sortKey{kind: keyTime}, ptr("2026-01-02T03:04:05.000000006Z"), at},
+		{"nullable", sortKey{kind: keyTime, nullable: true}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.key.parse(tt.value)
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortKeyPast(t *testing.T) {
+	key := sortKey{expr: "ts_rank(search_vector, to_tsquery(?, ?))::float8", vars: []interface{}{"english", "a"}, kind: keyFloat, desc: true}
+
+	condition, vars, ok := key.past(0.5, false)
+	if !ok || condition != "ts_rank(search_vector, to_tsquery(?, ?))::float8 < ?" {
+		t.Errorf("past() = %s, %v", condition, ok)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{"english", "a", 0.5}) {
+		t.Errorf("past() vars = %v, want the key vars before the value", vars)
+	}
+
+	if condition, _, _ := key.past(0.5, true); condition != "ts_rank(search_vector, to_tsquery(?, ?))::float8 > ?" {
+		t.Errorf("past() backward = %s", condition)
+	}
+
+	// Going forward nothing comes after the NULLs at the end
+	if _, _, ok := dueAtKey(false).past(nil, false); ok {
+		t.Errorf("past() from a null going forward = ok, want none")
+	}
+}
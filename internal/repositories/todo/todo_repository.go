@@ -1,14 +1,39 @@
 package todo
 
 import (
+	"errors"
 	"github.com/savioruz/mikti-task/internal/domain/entity"
 	"github.com/savioruz/mikti-task/internal/domain/model"
 	"github.com/savioruz/mikti-task/internal/repositories"
 	"gorm.io/gorm"
+	"time"
 )
 
+// ErrStaleVersion is returned by Update when the todo was changed since it was read
+var ErrStaleVersion = errors.New("todo version is stale")
+
+// PositionStep is the gap left between todos added to the end of a list and between rebalanced ones
+const PositionStep = 1024
+
 type TodoRepository interface {
 	repositories.Repository[entity.Todo]
 	GetByID(db *gorm.DB, todo *entity.Todo, id string) error
-	GetPaginated(db *gorm.DB, todos *[]entity.Todo, opts model.TodoQueryOptions) (int64, error)
+	GetPaginated(db *gorm.DB, todos *[]entity.Todo, opts model.TodoQueryOptions) (*model.TodoPage, error)
+	ReplaceTags(db *gorm.DB, todo *entity.Todo, tags []entity.Tag) error
+	GetChildren(db *gorm.DB, todos *[]entity.Todo, parentID string) error
+	GetDescendantIDs(db *gorm.DB, id string) ([]string, error)
+	UpdateDoneByIDs(db *gorm.DB, ids []string, done bool) error
+	DeleteByIDs(db *gorm.DB, ids []string) error
+	ReparentChildren(db *gorm.DB, parentID string, newParentID *string) ([]string, error)
+	ClearProject(db *gorm.DB, projectID string) ([]string, error)
+	DeleteByProjectID(db *gorm.DB, projectID string) ([]string, error)
+	GetByIDWithTrashed(db *gorm.DB, todo *entity.Todo, id string) error
+	GetTrashed(db *gorm.DB, todos *[]entity.Todo, opts model.TodoTrashOptions) (int64, error)
+	GetTrashedDescendantIDs(db *gorm.DB, id string) ([]string, error)
+	RestoreByIDs(db *gorm.DB, ids []string) error
+	Purge(db *gorm.DB, todo *entity.Todo) error
+	PurgeDeletedBefore(db *gorm.DB, before time.Time) (int64, error)
+	LastPosition(db *gorm.DB, parentID, projectID *string) (float64, error)
+	GetNeighbor(db *gorm.DB, todo *entity.Todo, anchor *entity.Todo, after bool, excludeID string) error
+	RebalancePositions(db *gorm.DB, parentID, projectID *string) ([]string, error)
 }
@@ -1,14 +1,36 @@
 package todo
 
 import (
-	"fmt"
 	"github.com/savioruz/mikti-task/internal/domain/entity"
 	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/platform/cursor"
 	"github.com/savioruz/mikti-task/internal/repositories"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+	"strings"
+	"time"
 )
 
+// childStatsSelect adds the direct children rollups read into entity.Todo
+const childStatsSelect = "todos.*, " +
+	"(SELECT COUNT(*) FROM todos AS children WHERE children.parent_id = todos.id AND children.deleted_at IS NULL) AS child_count, " +
+	"(SELECT COUNT(*) FROM todos AS children WHERE children.parent_id = todos.id AND children.deleted_at IS NULL AND children.done) AS child_done_count"
+
+// sharedProjectsSelect finds the projects shared with a user
+const sharedProjectsSelect = "SELECT resource_id FROM shares WHERE resource_type = 'project' AND user_id = ? AND deleted_at IS NULL"
+
+// sharedTodosSelect finds the todos shared with a user together with every subtask below them
+const sharedTodosSelect = `WITH RECURSIVE shared AS (
+		SELECT resource_id AS id FROM shares WHERE resource_type = 'todo' AND user_id = ? AND deleted_at IS NULL
+		UNION
+		SELECT t.id FROM todos t JOIN shared ON t.parent_id = shared.id
+	) SELECT id FROM shared`
+
+// searchConfig is the text search configuration of the search_vector column
+const searchConfig = "english"
+
 type TodoRepositoryImpl struct {
 	repositories.RepositoryImpl[entity.Todo]
 	Log *logrus.Logger
@@ -21,11 +43,144 @@ func NewTodoRepository(db *gorm.DB, log *logrus.Logger) *TodoRepositoryImpl {
 	}
 }
 
+// Create adds the todo at the end of its list unless it already has a position
+func (r *TodoRepositoryImpl) Create(db *gorm.DB, todo *entity.Todo) error {
+	if todo.Position == 0 {
+		last, err := r.LastPosition(db, todo.ParentID, todo.ProjectID)
+		if err != nil {
+			return err
+		}
+		todo.Position = last + PositionStep
+	}
+
+	return r.RepositoryImpl.Create(db, todo)
+}
+
+// Update saves the todo only while its row still has the version that was read and bumps the version,
+// ErrStaleVersion means another write got there first
+func (r *TodoRepositoryImpl) Update(db *gorm.DB, todo *entity.Todo) error {
+	version := todo.Version
+	todo.Version++
+
+	result := db.Model(todo).Select("*").Omit(clause.Associations).Where("version = ?", version).Updates(todo)
+	if result.Error != nil {
+		todo.Version = version
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		todo.Version = version
+		return ErrStaleVersion
+	}
+
+	return nil
+}
+
 func (r *TodoRepositoryImpl) GetByID(db *gorm.DB, todo *entity.Todo, id string) error {
-	return db.Where("id = ?", id).Take(&todo).Error
+	return db.Preload("Tags").Select(childStatsSelect).Where("id = ?", id).Take(&todo).Error
+}
+
+func (r *TodoRepositoryImpl) ReplaceTags(db *gorm.DB, todo *entity.Todo, tags []entity.Tag) error {
+	return db.Model(todo).Association("Tags").Replace(tags)
+}
+
+func (r *TodoRepositoryImpl) GetChildren(db *gorm.DB, todos *[]entity.Todo, parentID string) error {
+	return db.Model(&entity.Todo{}).
+		Preload("Tags").
+		Select(childStatsSelect).
+		Where("parent_id = ?", parentID).
+		Order("created_at ASC").
+		Find(todos).Error
+}
+
+// GetDescendantIDs returns the IDs of every subtask below the todo, at any depth
+func (r *TodoRepositoryImpl) GetDescendantIDs(db *gorm.DB, id string) ([]string, error) {
+	return r.descendantIDs(db, id, "deleted_at IS NULL")
+}
+
+// descendantIDs walks down the subtasks of a todo that meet the condition on deleted_at. The walk is raw SQL, so
+// it keeps to the active workspace itself.
+func (r *TodoRepositoryImpl) descendantIDs(db *gorm.DB, id, deleted string) ([]string, error) {
+	anchor, err := repositories.TenantCondition(db, "workspace_id")
+	if err != nil {
+		return nil, err
+	}
+	step, err := repositories.TenantCondition(db, "t.workspace_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	err = db.Raw(`WITH RECURSIVE descendants AS (
+			SELECT id FROM todos WHERE parent_id = ? AND `+deleted+` AND ?
+			UNION
+			SELECT t.id FROM todos t JOIN descendants d ON t.parent_id = d.id WHERE t.`+deleted+` AND ?
+		) SELECT id FROM descendants`, id, anchor, step).Scan(&ids).Error
+	return ids, err
+}
+
+func (r *TodoRepositoryImpl) UpdateDoneByIDs(db *gorm.DB, ids []string, done bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Model(&entity.Todo{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"done":    done,
+		"version": gorm.Expr("version + 1"),
+	}).Error
+}
+
+func (r *TodoRepositoryImpl) DeleteByIDs(db *gorm.DB, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Where("id IN ?", ids).Delete(&entity.Todo{}).Error
 }
 
-func (r *TodoRepositoryImpl) GetPaginated(db *gorm.DB, todos *[]entity.Todo, opts model.TodoQueryOptions) (int64, error) {
+// ReparentChildren moves the subtasks of a todo under another parent and returns their ids
+func (r *TodoRepositoryImpl) ReparentChildren(db *gorm.DB, parentID string, newParentID *string) ([]string, error) {
+	var ids []string
+	if err := db.Model(&entity.Todo{}).Where("parent_id = ?", parentID).Pluck("id", &ids).Error; err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return ids, db.Model(&entity.Todo{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"parent_id": newParentID,
+		"version":   gorm.Expr("version + 1"),
+	}).Error
+}
+
+// ClearProject moves the todos of a project to the inbox and returns their ids
+func (r *TodoRepositoryImpl) ClearProject(db *gorm.DB, projectID string) ([]string, error) {
+	ids, err := r.projectTodoIDs(db, projectID)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return ids, db.Model(&entity.Todo{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"project_id": nil,
+		"version":    gorm.Expr("version + 1"),
+	}).Error
+}
+
+// DeleteByProjectID moves the todos of a project to the trash and returns their ids
+func (r *TodoRepositoryImpl) DeleteByProjectID(db *gorm.DB, projectID string) ([]string, error) {
+	ids, err := r.projectTodoIDs(db, projectID)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return ids, db.Where("id IN ?", ids).Delete(&entity.Todo{}).Error
+}
+
+func (r *TodoRepositoryImpl) projectTodoIDs(db *gorm.DB, projectID string) ([]string, error) {
+	var ids []string
+	err := db.Model(&entity.Todo{}).Where("project_id = ?", projectID).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// GetByIDWithTrashed finds a todo whether or not it is in the trash
+func (r *TodoRepositoryImpl) GetByIDWithTrashed(db *gorm.DB, todo *entity.Todo, id string) error {
+	return db.Unscoped().Preload("Tags").Select(childStatsSelect).Where("id = ?", id).Take(&todo).Error
+}
+
+// GetTrashed lists soft-deleted todos, most recently deleted first
+func (r *TodoRepositoryImpl) GetTrashed(db *gorm.DB, todos *[]entity.Todo, opts model.TodoTrashOptions) (int64, error) {
 	if opts.Page <= 0 {
 		opts.Page = 1
 	}
@@ -33,42 +188,283 @@ func (r *TodoRepositoryImpl) GetPaginated(db *gorm.DB, todos *[]entity.Todo, opt
 		opts.Size = 10
 	}
 
-	query := r.buildPaginatedQuery(db, opts)
+	query := db.Unscoped().Model(&entity.Todo{}).Where("deleted_at IS NOT NULL")
+
+	if !opts.IsAdmin || (opts.IsAdmin && opts.UserID != nil) {
+		query = query.Where("user_id = ?", opts.UserID)
+	}
+
+	// Rows past the retention window are about to be purged and cannot be restored anymore
+	if opts.DeletedAfter != nil {
+		query = query.Where("deleted_at > ?", *opts.DeletedAfter)
+	}
 
-	// Get total count
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
 		return 0, err
 	}
 
-	// Get paginated results
 	offset := (opts.Page - 1) * opts.Size
-	if err := query.Offset(offset).Limit(opts.Size).Find(todos).Error; err != nil {
+	if err := query.Select(childStatsSelect).Preload("Tags").Order("deleted_at DESC").Offset(offset).Limit(opts.Size).Find(todos).Error; err != nil {
 		return 0, err
 	}
 
 	return totalCount, nil
 }
 
+// GetTrashedDescendantIDs returns the IDs of every trashed subtask below the todo, at any depth
+func (r *TodoRepositoryImpl) GetTrashedDescendantIDs(db *gorm.DB, id string) ([]string, error) {
+	return r.descendantIDs(db, id, "deleted_at IS NOT NULL")
+}
+
+func (r *TodoRepositoryImpl) RestoreByIDs(db *gorm.DB, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Unscoped().Model(&entity.Todo{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"deleted_at": nil,
+		"version":    gorm.Expr("version + 1"),
+	}).Error
+}
+
+// Purge hard-deletes a todo, the foreign keys remove its subtasks and tag links along with it
+func (r *TodoRepositoryImpl) Purge(db *gorm.DB, todo *entity.Todo) error {
+	return db.Unscoped().Delete(todo).Error
+}
+
+// PurgeDeletedBefore hard-deletes every todo that went to the trash before the given time
+func (r *TodoRepositoryImpl) PurgeDeletedBefore(db *gorm.DB, before time.Time) (int64, error) {
+	result := db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&entity.Todo{})
+	return result.RowsAffected, result.Error
+}
+
+// inList narrows a query to one list: the subtasks of a parent, or the top level todos of a project or the inbox
+func inList(db *gorm.DB, parentID, projectID *string) *gorm.DB {
+	if parentID != nil {
+		return db.Where("parent_id = ?", *parentID)
+	}
+	if projectID != nil {
+		return db.Where("parent_id IS NULL AND project_id = ?", *projectID)
+	}
+	return db.Where("parent_id IS NULL AND project_id IS NULL")
+}
+
+// LastPosition is the position of the last todo in a list, zero for an empty list
+func (r *TodoRepositoryImpl) LastPosition(db *gorm.DB, parentID, projectID *string) (float64, error) {
+	var last float64
+	err := inList(db.Model(&entity.Todo{}), parentID, projectID).Select("COALESCE(MAX(position), 0)").Scan(&last).Error
+	return last, err
+}
+
+// GetNeighbor finds the todo right after the anchor in its list, or right before it
+func (r *TodoRepositoryImpl) GetNeighbor(db *gorm.DB, todo *entity.Todo, anchor *entity.Todo, after bool, excludeID string) error {
+	query := inList(db, anchor.ParentID, anchor.ProjectID).Where("id NOT IN ?", []string{anchor.ID, excludeID})
+	if after {
+		query = query.Where("position >= ?", anchor.Position).Order("position ASC, created_at ASC, id ASC")
+	} else {
+		query = query.Where("position <= ?", anchor.Position).Order("position DESC, created_at DESC, id DESC")
+	}
+	return query.Take(todo).Error
+}
+
+// RebalancePositions spreads the positions of a list out evenly again, keeping the order of its todos, and
+// returns the ids of the todos it moved
+func (r *TodoRepositoryImpl) RebalancePositions(db *gorm.DB, parentID, projectID *string) ([]string, error) {
+	var ids []string
+	if err := inList(db.Model(&entity.Todo{}), parentID, projectID).
+		Order("position ASC, created_at ASC, id ASC").
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var positions strings.Builder
+	positions.WriteString("CASE id")
+	vars := make([]interface{}, 0, len(ids)*2)
+	for i, id := range ids {
+		positions.WriteString(" WHEN ? THEN ?::float8")
+		vars = append(vars, id, float64(i+1)*PositionStep)
+	}
+	positions.WriteString(" END")
+
+	// Only the spacing changes, the todos do not count as updated and keep their version, so a client editing one
+	// of them does not run into a conflict it did not cause
+	err := db.Model(&entity.Todo{}).Where("id IN ?", ids).UpdateColumns(map[string]interface{}{
+		"position": gorm.Expr(positions.String(), vars...),
+	}).Error
+	return ids, err
+}
+
+// GetPaginated reads a page of todos by page number or, when a cursor is given, by seeking past it.
+// One extra row is read to tell whether another page follows.
+func (r *TodoRepositoryImpl) GetPaginated(db *gorm.DB, todos *[]entity.Todo, opts model.TodoQueryOptions) (*model.TodoPage, error) {
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+	if opts.Size <= 0 {
+		opts.Size = 10
+	}
+
+	query := r.buildPaginatedQuery(db, opts)
+	page := &model.TodoPage{}
+
+	// Add filter expression if provided
+	if opts.Filter != nil {
+		condition, vars, err := compileFilter(opts.Filter)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition, vars...)
+	}
+
+	// Get total count, skipped unless asked for as it has to visit every matching row
+	if opts.WithTotal {
+		var totalCount int64
+		if err := query.Count(&totalCount).Error; err != nil {
+			return nil, err
+		}
+		page.TotalItems = &totalCount
+	}
+
+	keys, signature := r.sortKeys(opts)
+	backward := opts.Before != nil
+
+	token := opts.After
+	if backward {
+		token = opts.Before
+	}
+	if token != nil {
+		c, err := cursor.Decode(*token)
+		if err != nil {
+			return nil, err
+		}
+		condition, vars, err := seek(keys, signature, c, backward)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition, vars...)
+	} else {
+		query = query.Offset((opts.Page - 1) * opts.Size)
+	}
+
+	// Get paginated results
+	if opts.Query != nil {
+		// The title is short and comes back whole, the description only as the fragments around its matches
+		query = query.Select(childStatsSelect+", ts_headline(?, todos.title, to_tsquery(?, ?), ?) AS highlight"+
+			", NULLIF(ts_headline(?, COALESCE(todos.description, ''), to_tsquery(?, ?), ?), '') AS description_highlight"+
+			", ts_rank(search_vector, to_tsquery(?, ?))::float8 AS search_rank",
+			searchConfig, searchConfig, *opts.Query, "StartSel=<mark>, StopSel=</mark>, HighlightAll=true",
+			searchConfig, searchConfig, *opts.Query, "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5",
+			searchConfig, *opts.Query)
+	} else {
+		query = query.Select(childStatsSelect)
+	}
+
+	if err := query.Preload("Tags").Order(orderBy(keys, backward)).Limit(opts.Size + 1).Find(todos).Error; err != nil {
+		return nil, err
+	}
+
+	more := len(*todos) > opts.Size
+	if more {
+		*todos = (*todos)[:opts.Size]
+	}
+	if backward {
+		slices.Reverse(*todos)
+	}
+	if len(*todos) == 0 {
+		return page, nil
+	}
+
+	first := newCursor(keys, signature, &(*todos)[0])
+	last := newCursor(keys, signature, &(*todos)[len(*todos)-1])
+	switch {
+	case backward:
+		// The rows the cursor came from follow this page
+		page.NextCursor = last
+		if more {
+			page.PrevCursor = first
+		}
+	case opts.After != nil:
+		page.PrevCursor = first
+		if more {
+			page.NextCursor = last
+		}
+	default:
+		if opts.Page > 1 {
+			page.PrevCursor = first
+		}
+		if more {
+			page.NextCursor = last
+		}
+	}
+
+	return page, nil
+}
+
 func (r *TodoRepositoryImpl) buildPaginatedQuery(db *gorm.DB, opts model.TodoQueryOptions) *gorm.DB {
 	query := db.Model(&entity.Todo{})
 
-	// Add user filter only if not admin or if admin specified a userID
-	if !opts.IsAdmin || (opts.IsAdmin && opts.UserID != nil) {
-		query = query.Where("user_id = ?", opts.UserID)
+	// Users see their own todos and the ones shared with them, unless their workspace role lets them see all of
+	// them. An admin can narrow down to one owner.
+	switch {
+	case opts.IsAdmin:
+		if opts.UserID != nil {
+			query = query.Where("user_id = ?", opts.UserID)
+		}
+	case !opts.WorkspaceWide:
+		query = query.Where("(user_id = ? OR project_id IN ("+sharedProjectsSelect+") OR id IN ("+sharedTodosSelect+"))",
+			opts.UserID, opts.UserID, opts.UserID)
 	}
 
-	// Add title filter if provided
-	if opts.Title != nil && *opts.Title != "" {
-		query = query.Where("title LIKE ?", "%"+*opts.Title+"%")
+	// Add full-text filter if provided
+	if opts.Query != nil {
+		query = query.Where("search_vector @@ to_tsquery(?, ?)", searchConfig, *opts.Query)
 	}
 
-	// Add sorting
-	if opts.Sort != "" && opts.Order != "" {
-		sort := fmt.Sprintf("%s %s", opts.Sort, opts.Order)
-		query = query.Order(sort)
-	} else {
-		query = query.Order("created_at DESC")
+	// Add project filter if provided, inbox means todos without a project
+	if opts.ProjectID != nil {
+		if *opts.ProjectID == model.TodoProjectInbox {
+			query = query.Where("project_id IS NULL")
+		} else {
+			query = query.Where("project_id = ?", *opts.ProjectID)
+		}
+	}
+
+	// Add priority filter if provided
+	if opts.Priority != nil {
+		query = query.Where("priority = ?", *opts.Priority)
+	}
+
+	// Add tag filter if provided, matching any or all of the given names
+	if len(opts.Tags) > 0 {
+		tagged := db.Session(&gorm.Session{NewDB: true}).
+			Table("todo_tags").
+			Select("todo_tags.todo_id").
+			Joins("JOIN tags ON tags.id = todo_tags.tag_id").
+			Where("tags.name IN ? AND tags.deleted_at IS NULL", opts.Tags).
+			Group("todo_tags.todo_id")
+		if opts.TagMode == model.TodoTagModeAll {
+			tagged = tagged.Having("COUNT(DISTINCT tags.name) = ?", len(opts.Tags))
+		}
+		query = query.Where("id IN (?)", tagged)
+	}
+
+	// Add due date filters if provided
+	switch opts.Due {
+	case model.TodoDueOverdue:
+		query = query.Where("due_at < ? AND done = ?", time.Now(), false)
+	case model.TodoDueToday:
+		now := time.Now()
+		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		query = query.Where("due_at >= ? AND due_at < ?", startOfDay, startOfDay.AddDate(0, 0, 1))
+	}
+	if opts.DueBefore != nil {
+		query = query.Where("due_at < ?", *opts.DueBefore)
+	}
+	if opts.DueAfter != nil {
+		query = query.Where("due_at > ?", *opts.DueAfter)
 	}
 
 	return query
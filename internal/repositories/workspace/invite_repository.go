@@ -0,0 +1,12 @@
+package workspace
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type InviteRepository interface {
+	repositories.Repository[entity.WorkspaceInvite]
+	GetByTokenHash(db *gorm.DB, invite *entity.WorkspaceInvite, tokenHash string) error
+}
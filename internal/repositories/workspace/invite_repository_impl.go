@@ -0,0 +1,25 @@
+package workspace
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type InviteRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.WorkspaceInvite]
+	Log *logrus.Logger
+}
+
+func NewInviteRepository(db *gorm.DB, log *logrus.Logger) *InviteRepositoryImpl {
+	return &InviteRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.WorkspaceInvite]{DB: db},
+		Log:            log,
+	}
+}
+
+// GetByTokenHash finds an invite that has not been accepted yet
+func (r *InviteRepositoryImpl) GetByTokenHash(db *gorm.DB, invite *entity.WorkspaceInvite, tokenHash string) error {
+	return db.Where("token_hash = ? AND accepted_at IS NULL", tokenHash).Take(&invite).Error
+}
@@ -0,0 +1,15 @@
+package workspace
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type MemberRepository interface {
+	repositories.Repository[entity.WorkspaceMember]
+	GetByUser(db *gorm.DB, member *entity.WorkspaceMember, workspaceID, userID string) error
+	GetByWorkspace(db *gorm.DB, members *[]entity.WorkspaceMember, workspaceID string) error
+	GetByUserID(db *gorm.DB, members *[]entity.WorkspaceMember, userID string) error
+	Purge(db *gorm.DB, member *entity.WorkspaceMember) error
+}
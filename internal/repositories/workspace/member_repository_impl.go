@@ -0,0 +1,38 @@
+package workspace
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type MemberRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.WorkspaceMember]
+	Log *logrus.Logger
+}
+
+func NewMemberRepository(db *gorm.DB, log *logrus.Logger) *MemberRepositoryImpl {
+	return &MemberRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.WorkspaceMember]{DB: db},
+		Log:            log,
+	}
+}
+
+func (r *MemberRepositoryImpl) GetByUser(db *gorm.DB, member *entity.WorkspaceMember, workspaceID, userID string) error {
+	return db.Preload("User").Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Take(&member).Error
+}
+
+func (r *MemberRepositoryImpl) GetByWorkspace(db *gorm.DB, members *[]entity.WorkspaceMember, workspaceID string) error {
+	return db.Preload("User").Where("workspace_id = ?", workspaceID).Order("created_at ASC").Find(members).Error
+}
+
+// GetByUserID lists the memberships of a user together with their workspaces
+func (r *MemberRepositoryImpl) GetByUserID(db *gorm.DB, members *[]entity.WorkspaceMember, userID string) error {
+	return db.Preload("Workspace").Where("user_id = ?", userID).Order("created_at ASC").Find(members).Error
+}
+
+// Purge hard-deletes a membership so the user can be invited again
+func (r *MemberRepositoryImpl) Purge(db *gorm.DB, member *entity.WorkspaceMember) error {
+	return db.Unscoped().Delete(member).Error
+}
@@ -0,0 +1,12 @@
+package workspace
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type WorkspaceRepository interface {
+	repositories.Repository[entity.Workspace]
+	GetByID(db *gorm.DB, workspace *entity.Workspace, id string) error
+}
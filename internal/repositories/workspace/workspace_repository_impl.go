@@ -0,0 +1,24 @@
+package workspace
+
+import (
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/repositories"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type WorkspaceRepositoryImpl struct {
+	repositories.RepositoryImpl[entity.Workspace]
+	Log *logrus.Logger
+}
+
+func NewWorkspaceRepository(db *gorm.DB, log *logrus.Logger) *WorkspaceRepositoryImpl {
+	return &WorkspaceRepositoryImpl{
+		RepositoryImpl: repositories.RepositoryImpl[entity.Workspace]{DB: db},
+		Log:            log,
+	}
+}
+
+func (r *WorkspaceRepositoryImpl) GetByID(db *gorm.DB, workspace *entity.Workspace, id string) error {
+	return db.Where("id = ?", id).Take(&workspace).Error
+}
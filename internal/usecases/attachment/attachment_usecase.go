@@ -0,0 +1,13 @@
+package attachment
+
+import (
+	"context"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+type AttachmentUsecase interface {
+	Upload(ctx context.Context, request *model.AttachmentUploadRequest) (*model.AttachmentResponse, error)
+	GetAll(ctx context.Context, request *model.TodoGetRequest) (*model.Response[[]*model.AttachmentResponse], error)
+	Download(ctx context.Context, request *model.AttachmentGetRequest) (*model.AttachmentDownload, error)
+	Delete(ctx context.Context, request *model.AttachmentGetRequest) (bool, error)
+}
@@ -0,0 +1,254 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/helper"
+	"github.com/savioruz/mikti-task/internal/platform/storage"
+	"github.com/savioruz/mikti-task/internal/repositories/attachment"
+	"github.com/savioruz/mikti-task/internal/repositories/share"
+	"github.com/savioruz/mikti-task/internal/repositories/todo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// sniffLength is how much of a file http.DetectContentType looks at
+const sniffLength = 512
+
+type AttachmentUsecaseImpl struct {
+	DB                   *gorm.DB
+	Log                  *logrus.Logger
+	Validate             *validator.Validate
+	AttachmentRepository attachment.AttachmentRepository
+	TodoRepository       todo.TodoRepository
+	ShareRepository      share.ShareRepository
+	Storage              storage.Storage
+	// MaxSize is the largest accepted file in bytes
+	MaxSize int64
+	// AllowedTypes are the accepted media types, detected from the content instead of trusting the client
+	AllowedTypes []string
+	helper       *helper.ContextHelper
+}
+
+func NewAttachmentUsecaseImpl(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, attachmentRepository attachment.AttachmentRepository, todoRepository todo.TodoRepository, shareRepository share.ShareRepository, storage storage.Storage, maxSize int64, allowedTypes []string) *AttachmentUsecaseImpl {
+	return &AttachmentUsecaseImpl{
+		DB:                   db,
+		Log:                  log,
+		Validate:             validate,
+		AttachmentRepository: attachmentRepository,
+		TodoRepository:       todoRepository,
+		ShareRepository:      shareRepository,
+		Storage:              storage,
+		MaxSize:              maxSize,
+		AllowedTypes:         allowedTypes,
+		helper:               helper.NewContextHelper(),
+	}
+}
+
+func (u *AttachmentUsecaseImpl) Upload(ctx context.Context, request *model.AttachmentUploadRequest) (*model.AttachmentResponse, error) {
+	if err := u.Validate.Struct(request); err != nil || request.Size <= 0 {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if request.Size > u.MaxSize {
+		return nil, errors.New(http.StatusText(http.StatusRequestEntityTooLarge))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	todoData, err := u.getTodo(ctx, u.DB.WithContext(ctx), request.TodoID, model.ShareRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(request.Body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		u.Log.Errorf("failed to read upload: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !slices.Contains(u.AllowedTypes, mediaType) {
+		return nil, errors.New(http.StatusText(http.StatusUnsupportedMediaType))
+	}
+
+	attachmentData := &entity.Attachment{
+		ID:          uuid.NewString(),
+		TodoID:      todoData.ID,
+		Filename:    cleanFilename(request.Filename),
+		ContentType: contentType,
+		Size:        request.Size,
+		UserID:      claims.UserID,
+	}
+	attachmentData.StorageKey = fmt.Sprintf("todos/%s/%s", todoData.ID, attachmentData.ID)
+
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head), request.Body), request.Size)
+	if err := u.Storage.Put(ctx, attachmentData.StorageKey, body, request.Size, contentType); err != nil {
+		u.Log.Errorf("failed to store attachment: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.AttachmentRepository.Create(tx, attachmentData); err != nil {
+		u.Log.Errorf("failed to create attachment: %v", err)
+		u.removeFile(ctx, attachmentData.StorageKey)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		u.removeFile(ctx, attachmentData.StorageKey)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.AttachmentToResponse(attachmentData, u.helper.IsAdmin(ctx)), nil
+}
+
+func (u *AttachmentUsecaseImpl) GetAll(ctx context.Context, request *model.TodoGetRequest) (*model.Response[[]*model.AttachmentResponse], error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	db := u.DB.WithContext(ctx)
+	todoData, err := u.getTodo(ctx, db, request.ID, model.ShareRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []entity.Attachment
+	if err := u.AttachmentRepository.GetByTodoID(db, &attachments, todoData.ID); err != nil {
+		u.Log.Errorf("failed to get attachments: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return model.NewResponse(converter.AttachmentsToResponses(attachments, u.helper.IsAdmin(ctx)), nil), nil
+}
+
+func (u *AttachmentUsecaseImpl) Download(ctx context.Context, request *model.AttachmentGetRequest) (*model.AttachmentDownload, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	db := u.DB.WithContext(ctx)
+	if _, err := u.getTodo(ctx, db, request.TodoID, model.ShareRoleViewer); err != nil {
+		return nil, err
+	}
+
+	attachmentData := &entity.Attachment{}
+	if err := u.AttachmentRepository.GetByID(db, attachmentData, request.TodoID, request.ID); err != nil {
+		u.Log.Errorf("failed to get attachment: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	body, err := u.Storage.Get(ctx, attachmentData.StorageKey)
+	if err != nil {
+		u.Log.Errorf("failed to open attachment: %v", err)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, errors.New(http.StatusText(http.StatusNotFound))
+		}
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return &model.AttachmentDownload{
+		Attachment: converter.AttachmentToResponse(attachmentData, u.helper.IsAdmin(ctx)),
+		Body:       body,
+	}, nil
+}
+
+func (u *AttachmentUsecaseImpl) Delete(ctx context.Context, request *model.AttachmentGetRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if _, err := u.getTodo(ctx, tx, request.TodoID, model.ShareRoleEditor); err != nil {
+		return false, err
+	}
+
+	attachmentData := &entity.Attachment{}
+	if err := u.AttachmentRepository.GetByID(tx, attachmentData, request.TodoID, request.ID); err != nil {
+		u.Log.Errorf("failed to get attachment: %v", err)
+		return false, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.AttachmentRepository.Purge(tx, attachmentData); err != nil {
+		u.Log.Errorf("failed to delete attachment: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.removeFile(ctx, attachmentData.StorageKey)
+
+	return true, nil
+}
+
+// getTodo loads a todo the caller holds at least the role on, attachments follow the todo's access
+func (u *AttachmentUsecaseImpl) getTodo(ctx context.Context, db *gorm.DB, id, role string) (*entity.Todo, error) {
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(db, todoData, id); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	err := u.helper.VerifyAccess(ctx, todoData.UserID, role, func(userID string) (string, error) {
+		return u.ShareRepository.GetTodoRole(db, userID, todoData.ID)
+	})
+	if err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	return todoData, nil
+}
+
+// removeFile deletes a stored file whose row is gone, a failure only leaves an orphaned file behind
+func (u *AttachmentUsecaseImpl) removeFile(ctx context.Context, key string) {
+	if err := u.Storage.Delete(ctx, key); err != nil {
+		u.Log.Errorf("failed to delete attachment file %s: %v", key, err)
+	}
+}
+
+// cleanFilename keeps the base name of an uploaded file without control characters
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	return name
+}
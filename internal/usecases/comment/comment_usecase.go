@@ -0,0 +1,13 @@
+package comment
+
+import (
+	"context"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+type CommentUsecase interface {
+	Create(ctx context.Context, request *model.CommentCreateRequest) (*model.CommentResponse, error)
+	GetAll(ctx context.Context, request *model.CommentGetAllRequest) (*model.Response[[]*model.CommentResponse], error)
+	Update(ctx context.Context, id *model.CommentGetRequest, request *model.CommentUpdateRequest) (*model.CommentResponse, error)
+	Delete(ctx context.Context, request *model.CommentGetRequest) (bool, error)
+}
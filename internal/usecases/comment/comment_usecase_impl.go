@@ -0,0 +1,216 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/helper"
+	"github.com/savioruz/mikti-task/internal/repositories/comment"
+	"github.com/savioruz/mikti-task/internal/repositories/share"
+	"github.com/savioruz/mikti-task/internal/repositories/todo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+type CommentUsecaseImpl struct {
+	DB                *gorm.DB
+	Log               *logrus.Logger
+	Validate          *validator.Validate
+	CommentRepository comment.CommentRepository
+	TodoRepository    todo.TodoRepository
+	ShareRepository   share.ShareRepository
+	helper            *helper.ContextHelper
+}
+
+func NewCommentUsecaseImpl(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, commentRepository comment.CommentRepository, todoRepository todo.TodoRepository, shareRepository share.ShareRepository) *CommentUsecaseImpl {
+	return &CommentUsecaseImpl{
+		DB:                db,
+		Log:               log,
+		Validate:          validate,
+		CommentRepository: commentRepository,
+		TodoRepository:    todoRepository,
+		ShareRepository:   shareRepository,
+		helper:            helper.NewContextHelper(),
+	}
+}
+
+func (u *CommentUsecaseImpl) Create(ctx context.Context, request *model.CommentCreateRequest) (*model.CommentResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	todoData, err := u.getTodo(ctx, tx, request.TodoID, model.ShareRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	commentData := &entity.Comment{
+		ID:     uuid.NewString(),
+		TodoID: todoData.ID,
+		UserID: claims.UserID,
+		Body:   request.Body,
+	}
+
+	if err := u.CommentRepository.Create(tx, commentData); err != nil {
+		u.Log.Errorf("failed to create comment: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.CommentToResponse(commentData), nil
+}
+
+func (u *CommentUsecaseImpl) GetAll(ctx context.Context, request *model.CommentGetAllRequest) (*model.Response[[]*model.CommentResponse], error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	// Ensure valid pagination parameters
+	if request.Size <= 0 {
+		request.Size = 20 // Default page size
+	}
+	if request.Page <= 0 {
+		request.Page = 1 // Default page number
+	}
+
+	db := u.DB.WithContext(ctx)
+	todoData, err := u.getTodo(ctx, db, request.TodoID, model.ShareRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []entity.Comment
+	totalItems, err := u.CommentRepository.GetByTodoID(db, &comments, todoData.ID, request.Page, request.Size)
+	if err != nil {
+		u.Log.Errorf("failed to get comments: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.CommentsToPaginatedResponse(comments, totalItems, request.Page, request.Size), nil
+}
+
+// Update changes the body of a comment, only its author may reword it
+func (u *CommentUsecaseImpl) Update(ctx context.Context, id *model.CommentGetRequest, request *model.CommentUpdateRequest) (*model.CommentResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(id); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if _, err := u.getTodo(ctx, tx, id.TodoID, model.ShareRoleViewer); err != nil {
+		return nil, err
+	}
+
+	commentData := &entity.Comment{}
+	if err := u.CommentRepository.GetByID(tx, commentData, id.TodoID, id.ID); err != nil {
+		u.Log.Errorf("failed to get comment: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.helper.VerifyOwnership(ctx, commentData.UserID); err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	if commentData.Body != request.Body {
+		commentData.Body = request.Body
+		commentData.Edited = true
+	}
+
+	if err := u.CommentRepository.Update(tx, commentData); err != nil {
+		u.Log.Errorf("failed to update comment: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.CommentToResponse(commentData), nil
+}
+
+// Delete soft-deletes a comment, its author and the owners of the todo may remove it
+func (u *CommentUsecaseImpl) Delete(ctx context.Context, request *model.CommentGetRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData, err := u.getTodo(ctx, tx, request.TodoID, model.ShareRoleViewer)
+	if err != nil {
+		return false, err
+	}
+
+	commentData := &entity.Comment{}
+	if err := u.CommentRepository.GetByID(tx, commentData, request.TodoID, request.ID); err != nil {
+		u.Log.Errorf("failed to get comment: %v", err)
+		return false, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.helper.VerifyOwnership(ctx, commentData.UserID); err != nil {
+		err := u.helper.VerifyAccess(ctx, todoData.UserID, model.ShareRoleOwner, func(userID string) (string, error) {
+			return u.ShareRepository.GetTodoRole(tx, userID, todoData.ID)
+		})
+		if err != nil {
+			u.Log.Errorf("unauthorized access attempt: %v", err)
+			return false, errors.New(http.StatusText(http.StatusForbidden))
+		}
+	}
+
+	if err := u.CommentRepository.Delete(tx, commentData); err != nil {
+		u.Log.Errorf("failed to delete comment: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return true, nil
+}
+
+// getTodo loads a todo the caller holds at least the role on, the comments on it share its access
+func (u *CommentUsecaseImpl) getTodo(ctx context.Context, db *gorm.DB, id, role string) (*entity.Todo, error) {
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(db, todoData, id); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	err := u.helper.VerifyAccess(ctx, todoData.UserID, role, func(userID string) (string, error) {
+		return u.ShareRepository.GetTodoRole(db, userID, todoData.ID)
+	})
+	if err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	return todoData, nil
+}
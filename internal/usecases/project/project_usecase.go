@@ -0,0 +1,14 @@
+package project
+
+import (
+	"context"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+type ProjectUsecase interface {
+	Create(ctx context.Context, request *model.ProjectCreateRequest) (*model.ProjectResponse, error)
+	Update(ctx context.Context, id *model.ProjectUpdateIDRequest, request *model.ProjectUpdateRequest) (*model.ProjectResponse, error)
+	Delete(ctx context.Context, request *model.ProjectDeleteRequest) (bool, error)
+	Get(ctx context.Context, request *model.ProjectGetRequest) (*model.ProjectResponse, error)
+	GetAll(ctx context.Context, request *model.ProjectGetAllRequest) (*model.Response[[]*model.ProjectResponse], error)
+}
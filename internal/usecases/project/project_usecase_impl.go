@@ -0,0 +1,245 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/cache"
+	"github.com/savioruz/mikti-task/internal/platform/helper"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+	"github.com/savioruz/mikti-task/internal/repositories/project"
+	"github.com/savioruz/mikti-task/internal/repositories/share"
+	"github.com/savioruz/mikti-task/internal/repositories/todo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+type ProjectUsecaseImpl struct {
+	DB                *gorm.DB
+	Cache             *cache.ImplCache
+	Log               *logrus.Logger
+	Validate          *validator.Validate
+	ProjectRepository project.ProjectRepository
+	TodoRepository    todo.TodoRepository
+	ShareRepository   share.ShareRepository
+	helper            *helper.ContextHelper
+}
+
+func NewProjectUsecaseImpl(db *gorm.DB, c *cache.ImplCache, log *logrus.Logger, validate *validator.Validate, projectRepository project.ProjectRepository, todoRepository todo.TodoRepository, shareRepository share.ShareRepository) *ProjectUsecaseImpl {
+	return &ProjectUsecaseImpl{
+		DB:                db,
+		Cache:             c,
+		Log:               log,
+		Validate:          validate,
+		ProjectRepository: projectRepository,
+		TodoRepository:    todoRepository,
+		ShareRepository:   shareRepository,
+		helper:            helper.NewContextHelper(),
+	}
+}
+
+func (u *ProjectUsecaseImpl) Create(ctx context.Context, request *model.ProjectCreateRequest) (*model.ProjectResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	projectData := &entity.Project{
+		ID:     uuid.NewString(),
+		Name:   request.Name,
+		Color:  request.Color,
+		UserID: claims.UserID,
+	}
+
+	if err := u.ProjectRepository.Create(tx, projectData); err != nil {
+		u.Log.Errorf("failed to create project: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.ProjectToResponse(projectData, false), nil
+}
+
+func (u *ProjectUsecaseImpl) Update(ctx context.Context, id *model.ProjectUpdateIDRequest, request *model.ProjectUpdateRequest) (*model.ProjectResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if request.Name == nil && request.Color == nil && request.Archived == nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if err := u.Validate.Struct(id); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	projectData := &entity.Project{}
+	if err := u.ProjectRepository.GetByID(tx, projectData, id.ID); err != nil {
+		u.Log.Errorf("failed to get project: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, projectData, model.ShareRoleEditor); err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil {
+		projectData.Name = *request.Name
+	}
+	if request.Color != nil {
+		projectData.Color = request.Color
+	}
+	if request.Archived != nil {
+		projectData.Archived = *request.Archived
+	}
+
+	if err := u.ProjectRepository.Update(tx, projectData); err != nil {
+		u.Log.Errorf("failed to update project: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.ProjectToResponse(projectData, u.helper.IsAdmin(ctx)), nil
+}
+
+func (u *ProjectUsecaseImpl) Delete(ctx context.Context, request *model.ProjectDeleteRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	projectData := &entity.Project{}
+	if err := u.ProjectRepository.GetByID(tx, projectData, request.ID); err != nil {
+		u.Log.Errorf("failed to get project: %v", err)
+		return false, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, projectData, model.ShareRoleOwner); err != nil {
+		return false, err
+	}
+
+	// Todos go back to the inbox unless the caller asked to delete them with the project
+	var ids []string
+	var err error
+	if request.Mode == model.ProjectDeleteModeCascade {
+		if ids, err = u.TodoRepository.DeleteByProjectID(tx, projectData.ID); err != nil {
+			u.Log.Errorf("failed to delete project todos: %v", err)
+			return false, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	} else if ids, err = u.TodoRepository.ClearProject(tx, projectData.ID); err != nil {
+		u.Log.Errorf("failed to move project todos to inbox: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.ProjectRepository.Delete(tx, projectData); err != nil {
+		u.Log.Errorf("failed to delete project: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(projectData.WorkspaceID, ids)
+
+	return true, nil
+}
+
+func (u *ProjectUsecaseImpl) Get(ctx context.Context, request *model.ProjectGetRequest) (*model.ProjectResponse, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	projectData := &entity.Project{}
+	if err := u.ProjectRepository.GetByID(u.DB.WithContext(ctx), projectData, request.ID); err != nil {
+		u.Log.Errorf("failed to get project: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, u.DB.WithContext(ctx), projectData, model.ShareRoleViewer); err != nil {
+		return nil, err
+	}
+
+	return converter.ProjectToResponse(projectData, u.helper.IsAdmin(ctx)), nil
+}
+
+func (u *ProjectUsecaseImpl) GetAll(ctx context.Context, request *model.ProjectGetAllRequest) (*model.Response[[]*model.ProjectResponse], error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	// Guests only see their own and shared projects, other members see every project of the workspace
+	db := u.DB.WithContext(ctx)
+	var projects []entity.Project
+	if workspace, ok := tenant.FromContext(ctx); ok && model.WorkspaceShareRole(workspace.Role) != "" {
+		err = u.ProjectRepository.GetAll(db, &projects, request.Archived)
+	} else {
+		err = u.ProjectRepository.GetByUserID(db, &projects, claims.UserID, request.Archived)
+	}
+	if err != nil {
+		u.Log.Errorf("failed to get projects: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return model.NewResponse(converter.ProjectsToResponses(projects, false), nil), nil
+}
+
+// authorize checks the caller holds at least the role on the project, as its owner, an admin or through a share
+func (u *ProjectUsecaseImpl) authorize(ctx context.Context, db *gorm.DB, projectData *entity.Project, role string) error {
+	err := u.helper.VerifyAccess(ctx, projectData.UserID, role, func(userID string) (string, error) {
+		return u.ShareRepository.GetProjectRole(db, userID, projectData.ID)
+	})
+	if err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	return nil
+}
+
+// invalidateTodoCache drops the cached todos that moved out of a deleted project and the todo lists of its
+// workspace, which group and filter by project
+func (u *ProjectUsecaseImpl) invalidateTodoCache(workspaceID string, ids []string) {
+	for _, id := range ids {
+		if err := u.Cache.Delete(fmt.Sprintf("todos:get:%s:%s", workspaceID, id)); err != nil {
+			u.Log.Errorf("failed to delete todo cache: %v", err)
+		}
+	}
+	if err := u.Cache.DeletePattern(u.helper.ListCachePattern(workspaceID)); err != nil {
+		u.Log.Errorf("failed to delete list caches: %v", err)
+	}
+}
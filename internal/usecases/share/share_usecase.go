@@ -0,0 +1,12 @@
+package share
+
+import (
+	"context"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+type ShareUsecase interface {
+	Create(ctx context.Context, request *model.ShareCreateRequest) (*model.ShareResponse, error)
+	GetAll(ctx context.Context, request *model.ShareGetAllRequest) (*model.Response[[]*model.ShareResponse], error)
+	Delete(ctx context.Context, request *model.ShareDeleteRequest) (bool, error)
+}
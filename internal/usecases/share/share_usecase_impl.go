@@ -0,0 +1,244 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/cache"
+	"github.com/savioruz/mikti-task/internal/platform/helper"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+	"github.com/savioruz/mikti-task/internal/repositories/project"
+	"github.com/savioruz/mikti-task/internal/repositories/share"
+	"github.com/savioruz/mikti-task/internal/repositories/todo"
+	"github.com/savioruz/mikti-task/internal/repositories/user"
+	"github.com/savioruz/mikti-task/internal/repositories/workspace"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+type ShareUsecaseImpl struct {
+	DB                *gorm.DB
+	Cache             *cache.ImplCache
+	Log               *logrus.Logger
+	Validate          *validator.Validate
+	ShareRepository   share.ShareRepository
+	TodoRepository    todo.TodoRepository
+	ProjectRepository project.ProjectRepository
+	UserRepository    user.UserRepository
+	MemberRepository  workspace.MemberRepository
+	helper            *helper.ContextHelper
+}
+
+func NewShareUsecaseImpl(db *gorm.DB, c *cache.ImplCache, log *logrus.Logger, validate *validator.Validate, shareRepository share.ShareRepository, todoRepository todo.TodoRepository, projectRepository project.ProjectRepository, userRepository user.UserRepository, memberRepository workspace.MemberRepository) *ShareUsecaseImpl {
+	return &ShareUsecaseImpl{
+		DB:                db,
+		Cache:             c,
+		Log:               log,
+		Validate:          validate,
+		ShareRepository:   shareRepository,
+		TodoRepository:    todoRepository,
+		ProjectRepository: projectRepository,
+		UserRepository:    userRepository,
+		MemberRepository:  memberRepository,
+		helper:            helper.NewContextHelper(),
+	}
+}
+
+// Create grants a user a role on a todo or project, granting it again changes the role
+func (u *ShareUsecaseImpl) Create(ctx context.Context, request *model.ShareCreateRequest) (*model.ShareResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	ownerID, err := u.getResource(ctx, tx, request.ResourceType, request.ResourceID, model.ShareRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient := &entity.User{}
+	if err := u.UserRepository.GetByEmail(tx, recipient, request.Email); err != nil {
+		u.Log.Errorf("failed to get user: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	// The owner already has every right, a grant to them would never be used
+	if recipient.ID == ownerID {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	shareData := &entity.Share{}
+	if err := u.ShareRepository.GetByUser(tx, shareData, request.ResourceType, request.ResourceID, recipient.ID); err == nil {
+		shareData.Role = request.Role
+		shareData.GrantedBy = claims.UserID
+		if err := u.ShareRepository.Update(tx, shareData); err != nil {
+			u.Log.Errorf("failed to update share: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	} else {
+		shareData = &entity.Share{
+			ID:           uuid.NewString(),
+			ResourceType: request.ResourceType,
+			ResourceID:   request.ResourceID,
+			UserID:       recipient.ID,
+			Role:         request.Role,
+			GrantedBy:    claims.UserID,
+		}
+		if err := u.ShareRepository.Create(tx, shareData); err != nil {
+			u.Log.Errorf("failed to create share: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	// A recipient from outside the workspace joins it as a guest, which only shows them what is shared
+	if active, ok := tenant.FromContext(ctx); ok {
+		if err := u.MemberRepository.GetByUser(tx, &entity.WorkspaceMember{}, active.ID, recipient.ID); err != nil {
+			member := &entity.WorkspaceMember{
+				ID:          uuid.NewString(),
+				WorkspaceID: active.ID,
+				UserID:      recipient.ID,
+				Role:        model.WorkspaceRoleGuest,
+			}
+			if err := u.MemberRepository.Create(tx, member); err != nil {
+				u.Log.Errorf("failed to create workspace member: %v", err)
+				return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if active, ok := tenant.FromContext(ctx); ok {
+		u.invalidateListCache(active.ID)
+	}
+
+	shareData.User = *recipient
+
+	return converter.ShareToResponse(shareData), nil
+}
+
+// GetAll lists who a todo or project is shared with, everyone with access may see it
+func (u *ShareUsecaseImpl) GetAll(ctx context.Context, request *model.ShareGetAllRequest) (*model.Response[[]*model.ShareResponse], error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	db := u.DB.WithContext(ctx)
+	if _, err := u.getResource(ctx, db, request.ResourceType, request.ResourceID, model.ShareRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var shares []entity.Share
+	if err := u.ShareRepository.GetByResource(db, &shares, request.ResourceType, request.ResourceID); err != nil {
+		u.Log.Errorf("failed to get shares: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return model.NewResponse(converter.SharesToResponses(shares), nil), nil
+}
+
+// Delete revokes a share, owners may revoke any share and a recipient may give up their own
+func (u *ShareUsecaseImpl) Delete(ctx context.Context, request *model.ShareDeleteRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return false, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	shareData := &entity.Share{}
+	if err := u.ShareRepository.GetByID(tx, shareData, request.ResourceType, request.ResourceID, request.ID); err != nil {
+		u.Log.Errorf("failed to get share: %v", err)
+		return false, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if shareData.UserID != claims.UserID {
+		if _, err := u.getResource(ctx, tx, request.ResourceType, request.ResourceID, model.ShareRoleOwner); err != nil {
+			return false, err
+		}
+	}
+
+	if err := u.ShareRepository.Purge(tx, shareData); err != nil {
+		u.Log.Errorf("failed to delete share: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	// The recipient's lists still hold what the share showed them
+	if active, ok := tenant.FromContext(ctx); ok {
+		u.invalidateListCache(active.ID)
+	}
+
+	return true, nil
+}
+
+// getResource checks the caller holds at least the role on the todo or project and returns its owner
+func (u *ShareUsecaseImpl) getResource(ctx context.Context, db *gorm.DB, resourceType, id, role string) (string, error) {
+	var ownerID string
+	var grant func(userID string) (string, error)
+
+	switch resourceType {
+	case model.ShareResourceTodo:
+		todoData := &entity.Todo{}
+		if err := u.TodoRepository.GetByID(db, todoData, id); err != nil {
+			u.Log.Errorf("failed to get todo: %v", err)
+			return "", errors.New(http.StatusText(http.StatusNotFound))
+		}
+		ownerID = todoData.UserID
+		grant = func(userID string) (string, error) {
+			return u.ShareRepository.GetTodoRole(db, userID, todoData.ID)
+		}
+	case model.ShareResourceProject:
+		projectData := &entity.Project{}
+		if err := u.ProjectRepository.GetByID(db, projectData, id); err != nil {
+			u.Log.Errorf("failed to get project: %v", err)
+			return "", errors.New(http.StatusText(http.StatusNotFound))
+		}
+		ownerID = projectData.UserID
+		grant = func(userID string) (string, error) {
+			return u.ShareRepository.GetProjectRole(db, userID, projectData.ID)
+		}
+	default:
+		return "", errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if err := u.helper.VerifyAccess(ctx, ownerID, role, grant); err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return "", errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	return ownerID, nil
+}
+
+// invalidateListCache drops the todo lists cached for a workspace, since the shared lists of the recipient change with a grant
+func (u *ShareUsecaseImpl) invalidateListCache(workspaceID string) {
+	if err := u.Cache.DeletePattern(u.helper.ListCachePattern(workspaceID)); err != nil {
+		u.Log.Errorf("failed to delete list caches: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package tag
+
+import (
+	"context"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+)
+
+type TagUsecase interface {
+	Create(ctx context.Context, request *model.TagCreateRequest) (*model.TagResponse, error)
+	Update(ctx context.Context, id *model.TagUpdateIDRequest, request *model.TagUpdateRequest) (*model.TagResponse, error)
+	Delete(ctx context.Context, request *model.TagDeleteRequest) (bool, error)
+	Get(ctx context.Context, request *model.TagGetRequest) (*model.TagResponse, error)
+	GetAll(ctx context.Context) (*model.Response[[]*model.TagResponse], error)
+}
@@ -0,0 +1,225 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/cache"
+	"github.com/savioruz/mikti-task/internal/platform/helper"
+	"github.com/savioruz/mikti-task/internal/repositories/tag"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+)
+
+type TagUsecaseImpl struct {
+	DB            *gorm.DB
+	Cache         *cache.ImplCache
+	Log           *logrus.Logger
+	Validate      *validator.Validate
+	TagRepository tag.TagRepository
+	helper        *helper.ContextHelper
+}
+
+func NewTagUsecaseImpl(db *gorm.DB, c *cache.ImplCache, log *logrus.Logger, validate *validator.Validate, tagRepository tag.TagRepository) *TagUsecaseImpl {
+	return &TagUsecaseImpl{
+		DB:            db,
+		Cache:         c,
+		Log:           log,
+		Validate:      validate,
+		TagRepository: tagRepository,
+		helper:        helper.NewContextHelper(),
+	}
+}
+
+func (u *TagUsecaseImpl) Create(ctx context.Context, request *model.TagCreateRequest) (*model.TagResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	request.Name = strings.TrimSpace(request.Name)
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	existingTag := &entity.Tag{}
+	if err := u.TagRepository.GetByName(tx, existingTag, claims.UserID, request.Name); err == nil {
+		u.Log.Errorf("tag already exists: %v", request.Name)
+		return nil, errors.New(http.StatusText(http.StatusConflict))
+	}
+
+	tagData := &entity.Tag{
+		ID:     uuid.NewString(),
+		Name:   request.Name,
+		UserID: claims.UserID,
+	}
+
+	if err := u.TagRepository.Create(tx, tagData); err != nil {
+		u.Log.Errorf("failed to create tag: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.TagToResponse(tagData), nil
+}
+
+func (u *TagUsecaseImpl) Update(ctx context.Context, id *model.TagUpdateIDRequest, request *model.TagUpdateRequest) (*model.TagResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(id); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	tagData := &entity.Tag{}
+	if err := u.TagRepository.GetByID(tx, tagData, id.ID); err != nil {
+		u.Log.Errorf("failed to get tag: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.helper.VerifyOwnership(ctx, tagData.UserID); err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	existingTag := &entity.Tag{}
+	if err := u.TagRepository.GetByName(tx, existingTag, tagData.UserID, request.Name); err == nil && existingTag.ID != tagData.ID {
+		u.Log.Errorf("tag already exists: %v", request.Name)
+		return nil, errors.New(http.StatusText(http.StatusConflict))
+	}
+
+	tagData.Name = request.Name
+
+	var todos []entity.Todo
+	if err := u.TagRepository.GetTaggedTodos(tx, &todos, tagData.ID); err != nil {
+		u.Log.Errorf("failed to get tagged todos: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.TagRepository.Update(tx, tagData); err != nil {
+		u.Log.Errorf("failed to update tag: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(todos)
+
+	return converter.TagToResponse(tagData), nil
+}
+
+func (u *TagUsecaseImpl) Delete(ctx context.Context, request *model.TagDeleteRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	tagData := &entity.Tag{}
+	if err := u.TagRepository.GetByID(tx, tagData, request.ID); err != nil {
+		u.Log.Errorf("failed to get tag: %v", err)
+		return false, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.helper.VerifyOwnership(ctx, tagData.UserID); err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return false, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	// The todos have to be found before the tag is detached from them
+	var todos []entity.Todo
+	if err := u.TagRepository.GetTaggedTodos(tx, &todos, tagData.ID); err != nil {
+		u.Log.Errorf("failed to get tagged todos: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.TagRepository.Delete(tx, tagData); err != nil {
+		u.Log.Errorf("failed to delete tag: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(todos)
+
+	return true, nil
+}
+
+func (u *TagUsecaseImpl) Get(ctx context.Context, request *model.TagGetRequest) (*model.TagResponse, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	tagData := &entity.Tag{}
+	if err := u.TagRepository.GetByID(u.DB.WithContext(ctx), tagData, request.ID); err != nil {
+		u.Log.Errorf("failed to get tag: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.helper.VerifyOwnership(ctx, tagData.UserID); err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	return converter.TagToResponse(tagData), nil
+}
+
+func (u *TagUsecaseImpl) GetAll(ctx context.Context) (*model.Response[[]*model.TagResponse], error) {
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	var tags []entity.Tag
+	if err := u.TagRepository.GetByUserID(u.DB.WithContext(ctx), &tags, claims.UserID); err != nil {
+		u.Log.Errorf("failed to get tags: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return model.NewResponse(converter.TagsToResponses(tags), nil), nil
+}
+
+// invalidateTodoCache drops the cached copies of the todos carrying a tag that was renamed or deleted, and the todo
+// lists of their workspaces, which show and filter by tag names
+func (u *TagUsecaseImpl) invalidateTodoCache(todos []entity.Todo) {
+	workspaces := map[string]bool{}
+	for _, todoData := range todos {
+		if err := u.Cache.Delete(fmt.Sprintf("todos:get:%s:%s", todoData.WorkspaceID, todoData.ID)); err != nil {
+			u.Log.Errorf("failed to delete todo cache: %v", err)
+		}
+		workspaces[todoData.WorkspaceID] = true
+	}
+	for workspaceID := range workspaces {
+		if err := u.Cache.DeletePattern(u.helper.ListCachePattern(workspaceID)); err != nil {
+			u.Log.Errorf("failed to delete list caches: %v", err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/repositories/todo"
+	"gorm.io/gorm"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// GetActivity lists the history of a todo newest first, everyone who can see the todo can see its history
+func (u *TodoUsecaseImpl) GetActivity(ctx context.Context, request *model.TodoActivityGetAllRequest) (*model.Response[[]*model.TodoActivityResponse], error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	// Ensure valid pagination parameters
+	if request.Size <= 0 {
+		request.Size = 20 // Default page size
+	}
+	if request.Page <= 0 {
+		request.Page = 1 // Default page number
+	}
+
+	db := u.DB.WithContext(ctx)
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByIDWithTrashed(db, todoData, request.TodoID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, db, todoData, model.ShareRoleViewer); err != nil {
+		return nil, err
+	}
+
+	var activities []entity.TodoActivity
+	totalItems, err := u.ActivityRepository.GetByTodoID(db, &activities, todoData.ID, request.Page, request.Size)
+	if err != nil {
+		u.Log.Errorf("failed to get todo activity: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.TodoActivitiesToPaginatedResponse(activities, totalItems, request.Page, request.Size), nil
+}
+
+// Revert puts a todo back to the state it had after an earlier entry of its history. The revert is recorded
+// as a change of its own, so it can be reverted in turn.
+func (u *TodoUsecaseImpl) Revert(ctx context.Context, request *model.TodoRevertRequest) (*model.TodoResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, todoData, request.TodoID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleEditor); err != nil {
+		return nil, err
+	}
+
+	activity := &entity.TodoActivity{}
+	if err := u.ActivityRepository.GetByID(tx, activity, todoData.ID, request.ActivityID); err != nil {
+		u.Log.Errorf("failed to get todo activity: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	target := &model.TodoSnapshot{}
+	if err := json.Unmarshal([]byte(activity.Snapshot), target); err != nil {
+		u.Log.Errorf("failed to read todo snapshot: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	before := converter.TodoToSnapshot(todoData)
+
+	tags, replaceTags, err := u.applySnapshot(tx, todoData, before, target)
+	if err != nil {
+		if err.Error() == http.StatusText(http.StatusInternalServerError) {
+			return nil, err
+		}
+		// Whatever keeps the todo from going back is a conflict with its state today
+		return nil, errors.New(http.StatusText(http.StatusConflict))
+	}
+
+	if err := u.TodoRepository.Update(tx, todoData); err != nil {
+		u.Log.Errorf("failed to revert todo: %v", err)
+		if errors.Is(err, todo.ErrStaleVersion) {
+			return nil, errors.New(http.StatusText(http.StatusConflict))
+		}
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if replaceTags {
+		if err := u.TodoRepository.ReplaceTags(tx, todoData, tags); err != nil {
+			u.Log.Errorf("failed to replace todo tags: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+		todoData.Tags = tags
+	}
+
+	if err := u.record(ctx, tx, model.TodoActivityReverted, todoData, before); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(todoData)
+	u.invalidateTodoCaches(todoData.WorkspaceID, parentIDs(todoData, before))
+	u.invalidateListCache(todoData.WorkspaceID)
+
+	return converter.TodoToResponse(todoData, false), nil
+}
+
+// applySnapshot sets the fields of a todo to the ones of a snapshot, parents, projects and rules are only checked
+// when they change. The tags are resolved but left for the caller to replace once the todo is saved, replaceTags
+// tells whether they changed at all.
+func (u *TodoUsecaseImpl) applySnapshot(tx *gorm.DB, todoData *entity.Todo, before, target *model.TodoSnapshot) ([]entity.Tag, bool, error) {
+	todoData.Title = target.Title
+	todoData.Description = target.Description
+	todoData.Done = target.Done
+	todoData.Priority = converter.PriorityToLevel(target.Priority)
+	todoData.DueAt = target.DueAt
+	todoData.StartAt = target.StartAt
+
+	if !validSchedule(todoData) {
+		return nil, false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if !equalPointers(target.ParentID, before.ParentID) {
+		if target.ParentID != nil {
+			if err := u.checkParent(tx, todoData, *target.ParentID); err != nil {
+				return nil, false, err
+			}
+		}
+		todoData.ParentID = target.ParentID
+	}
+	if !equalPointers(target.ProjectID, before.ProjectID) {
+		if target.ProjectID != nil {
+			if err := u.checkProject(tx, todoData.UserID, *target.ProjectID); err != nil {
+				return nil, false, err
+			}
+		}
+		todoData.ProjectID = target.ProjectID
+	}
+
+	if !equalPointers(target.Recurrence, before.Recurrence) || !equalPointers(target.Timezone, before.Timezone) {
+		if target.Recurrence == nil {
+			clearRecurrence(todoData)
+		} else if err := u.setRecurrence(todoData, *target.Recurrence, target.Timezone); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if err := u.appendToList(tx, todoData, before); err != nil {
+		return nil, false, err
+	}
+
+	if slices.Equal(target.Tags, before.Tags) {
+		return nil, false, nil
+	}
+
+	tags, err := u.resolveTags(tx, todoData.UserID, target.Tags)
+	if err != nil {
+		u.Log.Errorf("failed to resolve tags: %v", err)
+		return nil, false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return tags, true, nil
+}
+
+// record adds an entry to the history of a todo with the fields that changed since before, which is nil for a
+// new todo. Updates that end up changing nothing are not recorded.
+func (u *TodoUsecaseImpl) record(ctx context.Context, tx *gorm.DB, action string, todoData *entity.Todo, before *model.TodoSnapshot) error {
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	after := converter.TodoToSnapshot(todoData)
+	changes, err := diffSnapshots(before, after)
+	if err != nil {
+		u.Log.Errorf("failed to compare todo snapshots: %v", err)
+		return errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	switch action {
+	case model.TodoActivityUpdated, model.TodoActivityCompleted, model.TodoActivityReverted:
+		if len(changes) == 0 {
+			return nil
+		}
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		u.Log.Errorf("failed to encode todo changes: %v", err)
+		return errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	snapshotJSON, err := json.Marshal(after)
+	if err != nil {
+		u.Log.Errorf("failed to encode todo snapshot: %v", err)
+		return errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	activity := &entity.TodoActivity{
+		ID:       uuid.NewString(),
+		TodoID:   todoData.ID,
+		UserID:   claims.UserID,
+		Action:   action,
+		Changes:  string(changesJSON),
+		Snapshot: string(snapshotJSON),
+	}
+	if err := u.ActivityRepository.Create(tx, activity); err != nil {
+		u.Log.Errorf("failed to record todo activity: %v", err)
+		return errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return nil
+}
+
+// diffSnapshots lists the fields that differ in the order of the snapshot, a missing before compares against an
+// empty todo and reports null as the earlier value
+func diffSnapshots(before, after *model.TodoSnapshot) ([]*model.TodoActivityChange, error) {
+	created := before == nil
+	if created {
+		before = &model.TodoSnapshot{Priority: model.TodoPriorityNone, Tags: []string{}}
+	}
+
+	changes := []*model.TodoActivityChange{}
+	b, a := reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem()
+	for i := 0; i < b.NumField(); i++ {
+		beforeJSON, err := json.Marshal(b.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		afterJSON, err := json.Marshal(a.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(beforeJSON, afterJSON) {
+			continue
+		}
+
+		if created {
+			beforeJSON = json.RawMessage("null")
+		}
+		field := strings.Split(b.Type().Field(i).Tag.Get("json"), ",")[0]
+		changes = append(changes, &model.TodoActivityChange{Field: field, Before: beforeJSON, After: afterJSON})
+	}
+
+	return changes, nil
+}
+
+func equalPointers[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
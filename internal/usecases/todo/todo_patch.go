@@ -0,0 +1,149 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/jsonpatch"
+	"github.com/savioruz/mikti-task/internal/repositories/todo"
+	"net/http"
+	"slices"
+)
+
+// Patch applies a merge patch or a JSON patch to a todo. Unlike Update it can clear fields and edit the tags
+// one by one, the patched todo is validated like a new one and saved in a single transaction.
+func (u *TodoUsecaseImpl) Patch(ctx context.Context, request *model.TodoPatchRequest) (*model.TodoResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, todoData, request.ID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleEditor); err != nil {
+		return nil, err
+	}
+
+	if request.ExpectedVersion != nil && *request.ExpectedVersion != todoData.Version {
+		return nil, errors.New(http.StatusText(http.StatusPreconditionFailed))
+	}
+
+	document, err := json.Marshal(converter.TodoToPatchDocument(todoData))
+	if err != nil {
+		u.Log.Errorf("failed to encode todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	var patched []byte
+	if request.Format == model.TodoPatchMerge {
+		patched, err = jsonpatch.MergePatch(document, request.Patch)
+	} else {
+		patched, err = jsonpatch.Apply(document, request.Patch)
+	}
+	if err != nil {
+		u.Log.Errorf("failed to apply patch: %v", err)
+		if errors.Is(err, jsonpatch.ErrUnapplicable) {
+			return nil, errors.New(http.StatusText(http.StatusConflict))
+		}
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	target := &model.TodoPatchDocument{}
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		u.Log.Errorf("failed to decode patched todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if err := u.Validate.Struct(target); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if target.Timezone != nil && target.Recurrence == nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	target.Description = nonEmpty(target.Description)
+	slices.Sort(target.Tags)
+	target.Tags = slices.Compact(target.Tags)
+
+	before := converter.TodoToSnapshot(todoData)
+
+	// Completing an open occurrence of a repeating todo schedules the next one
+	completed := target.Done && !todoData.Done
+
+	tags, replaceTags, err := u.applySnapshot(tx, todoData, before, (*model.TodoSnapshot)(target))
+	if err != nil {
+		return nil, err
+	}
+
+	var next *entity.Todo
+	if completed && todoData.Recurrence != nil {
+		occurrence, err := u.nextOccurrence(todoData)
+		if err != nil {
+			return nil, err
+		}
+		next = occurrence
+	}
+
+	if err := u.TodoRepository.Update(tx, todoData); err != nil {
+		u.Log.Errorf("failed to patch todo: %v", err)
+		switch {
+		case errors.Is(err, todo.ErrStaleVersion) && request.ExpectedVersion != nil:
+			return nil, errors.New(http.StatusText(http.StatusPreconditionFailed))
+		case errors.Is(err, todo.ErrStaleVersion):
+			return nil, errors.New(http.StatusText(http.StatusConflict))
+		default:
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	if replaceTags {
+		if err := u.TodoRepository.ReplaceTags(tx, todoData, tags); err != nil {
+			u.Log.Errorf("failed to replace todo tags: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+		todoData.Tags = tags
+	}
+
+	action := model.TodoActivityUpdated
+	if completed {
+		action = model.TodoActivityCompleted
+	}
+	if err := u.record(ctx, tx, action, todoData, before); err != nil {
+		return nil, err
+	}
+
+	if next != nil {
+		if err := u.TodoRepository.Create(tx, next); err != nil {
+			u.Log.Errorf("failed to create next occurrence: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+
+		if err := u.record(ctx, tx, model.TodoActivityCreated, next, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(todoData)
+	u.invalidateTodoCaches(todoData.WorkspaceID, parentIDs(todoData, before))
+	u.invalidateListCache(todoData.WorkspaceID)
+
+	return converter.TodoToResponse(todoData, false), nil
+}
@@ -0,0 +1,170 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/repositories/todo"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+// minPositionGap is the closest two neighbours may get before their list is rebalanced
+const minPositionGap = 1e-6
+
+// errDensePositions means there is no room left between two neighbours
+var errDensePositions = errors.New("positions are too dense")
+
+// Reorder moves a todo within its list, the anchors have to be in the same list. When the neighbours are too close
+// to fit another todo between them the list is spread out again first.
+func (u *TodoUsecaseImpl) Reorder(ctx context.Context, request *model.TodoReorderRequest) (*model.TodoResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, todoData, request.ID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleEditor); err != nil {
+		return nil, err
+	}
+
+	var rebalanced []string
+	position, err := u.positionBetween(tx, todoData, request.After, request.Before)
+	if errors.Is(err, errDensePositions) {
+		rebalanced, err = u.TodoRepository.RebalancePositions(tx, todoData.ParentID, todoData.ProjectID)
+		if err != nil {
+			u.Log.Errorf("failed to rebalance positions: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+
+		// Rebalancing moved the todo as well
+		if err := u.TodoRepository.GetByID(tx, todoData, todoData.ID); err != nil {
+			u.Log.Errorf("failed to get todo: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+		position, err = u.positionBetween(tx, todoData, request.After, request.Before)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	todoData.Position = position
+
+	if err := u.TodoRepository.Update(tx, todoData); err != nil {
+		u.Log.Errorf("failed to reorder todo: %v", err)
+		if errors.Is(err, todo.ErrStaleVersion) {
+			return nil, errors.New(http.StatusText(http.StatusConflict))
+		}
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	// The rest of the list moved too, their cached copies hold the old positions
+	u.invalidateTodoCaches(todoData.WorkspaceID, rebalanced)
+	u.invalidateTodoCache(todoData)
+	u.invalidateListCache(todoData.WorkspaceID)
+
+	return converter.TodoToResponse(todoData, false), nil
+}
+
+// positionBetween works out the position that puts a todo right after one anchor and right before the other.
+// With a single anchor the neighbour on the other side is looked up, at either end of the list there is none.
+func (u *TodoUsecaseImpl) positionBetween(tx *gorm.DB, todoData *entity.Todo, afterID, beforeID *string) (float64, error) {
+	var after, before *entity.Todo
+	if afterID != nil {
+		anchor, err := u.listAnchor(tx, todoData, *afterID)
+		if err != nil {
+			return 0, err
+		}
+		after = anchor
+	}
+	if beforeID != nil {
+		anchor, err := u.listAnchor(tx, todoData, *beforeID)
+		if err != nil {
+			return 0, err
+		}
+		before = anchor
+	}
+
+	if before == nil {
+		neighbour := &entity.Todo{}
+		err := u.TodoRepository.GetNeighbor(tx, neighbour, after, true, todoData.ID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return after.Position + todo.PositionStep, nil
+		case err != nil:
+			u.Log.Errorf("failed to get next todo: %v", err)
+			return 0, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+		before = neighbour
+	}
+	if after == nil {
+		neighbour := &entity.Todo{}
+		err := u.TodoRepository.GetNeighbor(tx, neighbour, before, false, todoData.ID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return before.Position - todo.PositionStep, nil
+		case err != nil:
+			u.Log.Errorf("failed to get previous todo: %v", err)
+			return 0, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+		after = neighbour
+	}
+
+	if after.Position > before.Position {
+		return 0, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+	if before.Position-after.Position < minPositionGap {
+		return 0, errDensePositions
+	}
+
+	return after.Position + (before.Position-after.Position)/2, nil
+}
+
+// listAnchor reads a todo to place another one next to, both have to be in the same list
+func (u *TodoUsecaseImpl) listAnchor(tx *gorm.DB, todoData *entity.Todo, id string) (*entity.Todo, error) {
+	if id == todoData.ID {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	anchor := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, anchor, id); err != nil {
+		u.Log.Errorf("failed to get anchor todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if !equalPointers(anchor.ParentID, todoData.ParentID) || (todoData.ParentID == nil && !equalPointers(anchor.ProjectID, todoData.ProjectID)) {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	return anchor, nil
+}
+
+// appendToList puts a todo that moved to another list at the end of it
+func (u *TodoUsecaseImpl) appendToList(tx *gorm.DB, todoData *entity.Todo, before *model.TodoSnapshot) error {
+	if equalPointers(todoData.ParentID, before.ParentID) && equalPointers(todoData.ProjectID, before.ProjectID) {
+		return nil
+	}
+
+	last, err := u.TodoRepository.LastPosition(tx, todoData.ParentID, todoData.ProjectID)
+	if err != nil {
+		u.Log.Errorf("failed to get last position: %v", err)
+		return errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	todoData.Position = last + todo.PositionStep
+
+	return nil
+}
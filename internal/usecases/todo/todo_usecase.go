@@ -10,6 +10,17 @@ type TodoUsecase interface {
 	Update(ctx context.Context, request *model.TodoUpdateIDRequest, update *model.TodoUpdateRequest) (*model.TodoResponse, error)
 	Delete(ctx context.Context, request *model.TodoDeleteRequest) (bool, error)
 	Get(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error)
+	Bulk(ctx context.Context, request *model.TodoBulkRequest) (*model.TodoBulkResponse, error)
+	Skip(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error)
+	EndSeries(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error)
+	GetTrash(ctx context.Context, request *model.TodoTrashRequest) (*model.Response[[]*model.TodoResponse], error)
+	Restore(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error)
+	PurgeTrash(ctx context.Context) (int64, error)
+	GetChildren(ctx context.Context, request *model.TodoGetRequest) (*model.Response[[]*model.TodoResponse], error)
 	Search(ctx context.Context, request *model.TodoSearchRequest) (*model.Response[[]*model.TodoResponse], error)
 	GetAll(ctx context.Context, request *model.TodoGetAllRequest) (*model.Response[[]*model.TodoResponse], error)
+	GetActivity(ctx context.Context, request *model.TodoActivityGetAllRequest) (*model.Response[[]*model.TodoActivityResponse], error)
+	Revert(ctx context.Context, request *model.TodoRevertRequest) (*model.TodoResponse, error)
+	Patch(ctx context.Context, request *model.TodoPatchRequest) (*model.TodoResponse, error)
+	Reorder(ctx context.Context, request *model.TodoReorderRequest) (*model.TodoResponse, error)
 }
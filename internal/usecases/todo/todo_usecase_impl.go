@@ -10,31 +10,61 @@ import (
 	"github.com/savioruz/mikti-task/internal/domain/model"
 	"github.com/savioruz/mikti-task/internal/domain/model/converter"
 	"github.com/savioruz/mikti-task/internal/platform/cache"
+	"github.com/savioruz/mikti-task/internal/platform/cursor"
+	"github.com/savioruz/mikti-task/internal/platform/filter"
 	"github.com/savioruz/mikti-task/internal/platform/helper"
+	"github.com/savioruz/mikti-task/internal/platform/rrule"
+	"github.com/savioruz/mikti-task/internal/platform/storage"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+	"github.com/savioruz/mikti-task/internal/platform/tsquery"
+	"github.com/savioruz/mikti-task/internal/repositories/activity"
+	"github.com/savioruz/mikti-task/internal/repositories/attachment"
+	"github.com/savioruz/mikti-task/internal/repositories/project"
+	"github.com/savioruz/mikti-task/internal/repositories/share"
+	"github.com/savioruz/mikti-task/internal/repositories/tag"
 	"github.com/savioruz/mikti-task/internal/repositories/todo"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 	"net/http"
+	"strings"
 	"time"
 )
 
 type TodoUsecaseImpl struct {
-	DB             *gorm.DB
-	Cache          *cache.ImplCache
-	Log            *logrus.Logger
-	Validate       *validator.Validate
-	TodoRepository todo.TodoRepository
+	DB                *gorm.DB
+	Cache             *cache.ImplCache
+	Log               *logrus.Logger
+	Validate          *validator.Validate
+	TodoRepository    todo.TodoRepository
+	TagRepository     tag.TagRepository
+	ProjectRepository project.ProjectRepository
+	// ShareRepository looks up the roles other users were granted on todos and projects
+	ShareRepository share.ShareRepository
+	// AttachmentRepository and Storage are used to remove the files of purged todos
+	AttachmentRepository attachment.AttachmentRepository
+	Storage              storage.Storage
+	// ActivityRepository keeps the history of every todo
+	ActivityRepository activity.ActivityRepository
+	// TrashRetention is how long deleted todos can be restored, zero keeps them forever
+	TrashRetention time.Duration
 	helper         *helper.ContextHelper
 }
 
-func NewTodoUsecaseImpl(db *gorm.DB, c *cache.ImplCache, log *logrus.Logger, validate *validator.Validate, todoRepository todo.TodoRepository) *TodoUsecaseImpl {
+func NewTodoUsecaseImpl(db *gorm.DB, c *cache.ImplCache, log *logrus.Logger, validate *validator.Validate, todoRepository todo.TodoRepository, tagRepository tag.TagRepository, projectRepository project.ProjectRepository, shareRepository share.ShareRepository, attachmentRepository attachment.AttachmentRepository, storage storage.Storage, activityRepository activity.ActivityRepository, trashRetention time.Duration) *TodoUsecaseImpl {
 	return &TodoUsecaseImpl{
-		DB:             db,
-		Cache:          c,
-		Log:            log,
-		Validate:       validate,
-		TodoRepository: todoRepository,
-		helper:         helper.NewContextHelper(),
+		DB:                   db,
+		Cache:                c,
+		Log:                  log,
+		Validate:             validate,
+		TodoRepository:       todoRepository,
+		TagRepository:        tagRepository,
+		ProjectRepository:    projectRepository,
+		ShareRepository:      shareRepository,
+		AttachmentRepository: attachmentRepository,
+		Storage:              storage,
+		ActivityRepository:   activityRepository,
+		TrashRetention:       trashRetention,
+		helper:               helper.NewContextHelper(),
 	}
 }
 
@@ -42,156 +72,952 @@ func (u *TodoUsecaseImpl) Create(ctx context.Context, request *model.TodoCreateR
 	tx := u.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	todoData, err := u.create(ctx, tx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCaches(todoData.WorkspaceID, parentIDs(todoData, nil))
+	u.invalidateListCache(todoData.WorkspaceID)
+
+	return converter.TodoToResponse(todoData, false), nil
+}
+
+// create adds a todo within the caller's transaction
+func (u *TodoUsecaseImpl) create(ctx context.Context, tx *gorm.DB, request *model.TodoCreateRequest) (*entity.Todo, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	todoData := &entity.Todo{
+		ID:          uuid.NewString(),
+		Title:       request.Title,
+		Description: nonEmpty(request.Description),
+		Done:        false,
+		DueAt:       request.DueAt,
+		StartAt:     request.StartAt,
+		UserID:      claims.UserID,
+	}
+	if request.Priority != nil {
+		todoData.Priority = converter.PriorityToLevel(*request.Priority)
+	}
+
+	if !validSchedule(todoData) {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if request.Recurrence != nil {
+		if err := u.setRecurrence(todoData, *request.Recurrence, request.Timezone); err != nil {
+			return nil, err
+		}
+	} else if request.Timezone != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if request.ParentID != nil {
+		parent := &entity.Todo{}
+		if err := u.TodoRepository.GetByID(tx, parent, *request.ParentID); err != nil {
+			u.Log.Errorf("failed to get parent todo: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusNotFound))
+		}
+
+		if err := u.authorize(ctx, tx, parent, model.ShareRoleEditor); err != nil {
+			return nil, err
+		}
+
+		// Subtasks always belong to the owner of their parent and default to its project
+		todoData.ParentID = &parent.ID
+		todoData.UserID = parent.UserID
+		todoData.ProjectID = parent.ProjectID
+	}
+
+	if request.ProjectID != nil {
+		if request.ParentID == nil {
+			owner, err := u.projectOwner(ctx, tx, *request.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			todoData.UserID = owner
+		}
+
+		if err := u.checkProject(tx, todoData.UserID, *request.ProjectID); err != nil {
+			return nil, err
+		}
+		todoData.ProjectID = request.ProjectID
+	}
+
+	tags, err := u.resolveTags(tx, todoData.UserID, request.Tags)
+	if err != nil {
+		u.Log.Errorf("failed to resolve tags: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	todoData.Tags = tags
+
+	if err := u.TodoRepository.Create(tx, todoData); err != nil {
+		u.Log.Errorf("failed to create todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.record(ctx, tx, model.TodoActivityCreated, todoData, nil); err != nil {
+		return nil, err
+	}
+
+	return todoData, nil
+}
+
+func (u *TodoUsecaseImpl) Update(ctx context.Context, id *model.TodoUpdateIDRequest, request *model.TodoUpdateRequest) (*model.TodoResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	todoData, _, related, err := u.update(ctx, tx, id, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(todoData)
+	u.invalidateTodoCaches(todoData.WorkspaceID, related)
+	u.invalidateListCache(todoData.WorkspaceID)
+
+	return converter.TodoToResponse(todoData, false), nil
+}
+
+// update changes a todo within the caller's transaction, it also returns the next occurrence when completing a repeating
+// todo and the ids of the other todos whose cached copies changed with it
+func (u *TodoUsecaseImpl) update(ctx context.Context, tx *gorm.DB, id *model.TodoUpdateIDRequest, request *model.TodoUpdateRequest) (*entity.Todo, *entity.Todo, []string, error) {
+	if request.Done == nil && request.Title == nil && request.Description == nil && request.Priority == nil && request.DueAt == nil && request.StartAt == nil && request.ParentID == nil && request.ProjectID == nil && request.Tags == nil && request.Recurrence == nil && request.Timezone == nil {
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if err := u.Validate.Struct(id); err != nil {
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, todoData, id.ID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleEditor); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if request.ExpectedVersion != nil && *request.ExpectedVersion != todoData.Version {
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusPreconditionFailed))
+	}
+
+	before := converter.TodoToSnapshot(todoData)
+
+	// Completing an open occurrence of a repeating todo schedules the next one
+	completed := request.Done != nil && *request.Done && !todoData.Done
+
+	if request.Title != nil {
+		todoData.Title = *request.Title
+	}
+	if request.Description != nil {
+		todoData.Description = nonEmpty(request.Description)
+	}
+	if request.Done != nil {
+		todoData.Done = *request.Done
+	}
+	if request.Priority != nil {
+		todoData.Priority = converter.PriorityToLevel(*request.Priority)
+	}
+	if request.DueAt != nil {
+		todoData.DueAt = request.DueAt
+	}
+	if request.StartAt != nil {
+		todoData.StartAt = request.StartAt
+	}
+
+	if !validSchedule(todoData) {
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if request.Recurrence != nil {
+		if err := u.setRecurrence(todoData, *request.Recurrence, request.Timezone); err != nil {
+			return nil, nil, nil, err
+		}
+	} else if request.Timezone != nil {
+		if todoData.Recurrence == nil {
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusBadRequest))
+		}
+		todoData.RecurrenceTZ = request.Timezone
+	}
+
+	if request.ParentID != nil {
+		if err := u.checkParent(tx, todoData, *request.ParentID); err != nil {
+			return nil, nil, nil, err
+		}
+		todoData.ParentID = request.ParentID
+	}
+
+	if request.ProjectID != nil {
+		if err := u.checkProject(tx, todoData.UserID, *request.ProjectID); err != nil {
+			return nil, nil, nil, err
+		}
+		todoData.ProjectID = request.ProjectID
+	}
+
+	var tags []entity.Tag
+	if request.Tags != nil {
+		resolved, err := u.resolveTags(tx, todoData.UserID, request.Tags)
+		if err != nil {
+			u.Log.Errorf("failed to resolve tags: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+		tags = resolved
+		todoData.Tags = tags
+	}
+
+	if err := u.appendToList(tx, todoData, before); err != nil {
+		return nil, nil, nil, err
+	}
+
+	var next *entity.Todo
+	if completed && todoData.Recurrence != nil {
+		occurrence, err := u.nextOccurrence(todoData)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		next = occurrence
+	}
+
+	if err := u.TodoRepository.Update(tx, todoData); err != nil {
+		u.Log.Errorf("failed to update todo: %v", err)
+		switch {
+		case errors.Is(err, todo.ErrStaleVersion) && request.ExpectedVersion != nil:
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusPreconditionFailed))
+		case errors.Is(err, todo.ErrStaleVersion):
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusConflict))
+		default:
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	if request.Tags != nil {
+		if err := u.TodoRepository.ReplaceTags(tx, todoData, tags); err != nil {
+			u.Log.Errorf("failed to replace todo tags: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	related := parentIDs(todoData, before)
+	if request.Done != nil && request.Cascade != nil && *request.Cascade {
+		ids, err := u.TodoRepository.GetDescendantIDs(tx, todoData.ID)
+		if err != nil {
+			u.Log.Errorf("failed to get subtasks: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+
+		if err := u.TodoRepository.UpdateDoneByIDs(tx, ids, *request.Done); err != nil {
+			u.Log.Errorf("failed to update subtasks: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+
+		related = append(related, ids...)
+
+		todoData.ChildDoneCount = 0
+		if *request.Done {
+			todoData.ChildDoneCount = todoData.ChildCount
+		}
+	}
+
+	action := model.TodoActivityUpdated
+	if completed {
+		action = model.TodoActivityCompleted
+	}
+	if err := u.record(ctx, tx, action, todoData, before); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if next != nil {
+		if err := u.TodoRepository.Create(tx, next); err != nil {
+			u.Log.Errorf("failed to create next occurrence: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+
+		if err := u.record(ctx, tx, model.TodoActivityCreated, next, nil); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return todoData, next, related, nil
+}
+
+// Bulk runs every operation in one transaction, each behind a savepoint so a failed item can be undone on its own
+func (u *TodoUsecaseImpl) Bulk(ctx context.Context, request *model.TodoBulkRequest) (*model.TodoBulkResponse, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if _, err := u.helper.GetJWTClaims(ctx); err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	response := &model.TodoBulkResponse{
+		Atomic:  request.Atomic,
+		Results: make([]*model.TodoBulkResult, len(request.Operations)),
+	}
+	var todos []*entity.Todo
+	// Subtasks and parents changed along the way, grouped by workspace
+	touched := map[string][]string{}
+
+	for i := range request.Operations {
+		operation := &request.Operations[i]
+		result := &model.TodoBulkResult{Index: i, Op: operation.Op}
+		response.Results[i] = result
+
+		savepoint := fmt.Sprintf("bulk_%d", i)
+		if err := tx.SavePoint(savepoint).Error; err != nil {
+			u.Log.Errorf("failed to create savepoint: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+
+		todoData, related, status, err := u.bulkOperation(ctx, tx, operation)
+		if err != nil {
+			message := err.Error()
+			result.Status = bulkErrorStatus(err)
+			result.Error = &message
+			response.Failed++
+
+			if request.Atomic {
+				break
+			}
+
+			if err := tx.RollbackTo(savepoint).Error; err != nil {
+				u.Log.Errorf("failed to roll back to savepoint: %v", err)
+				return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+			}
+			continue
+		}
+
+		result.Status = status
+		if todoData != nil {
+			if status != http.StatusNoContent {
+				result.Data = converter.TodoToResponse(todoData, false)
+			}
+			todos = append(todos, todoData)
+			touched[todoData.WorkspaceID] = append(touched[todoData.WorkspaceID], related...)
+		}
+		response.Succeeded++
+	}
+
+	// In atomic mode one failure discards the whole batch, the other items report the dependency
+	if request.Atomic && response.Failed > 0 {
+		message := http.StatusText(http.StatusFailedDependency)
+		for i, result := range response.Results {
+			if result == nil {
+				result = &model.TodoBulkResult{Index: i, Op: request.Operations[i].Op}
+				response.Results[i] = result
+			} else if result.Error != nil {
+				continue
+			}
+			result.Status = http.StatusFailedDependency
+			result.Error = &message
+			result.Data = nil
+		}
+		response.Succeeded = 0
+		response.Failed = len(response.Results)
+
+		return response, nil
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	workspaces := map[string]bool{}
+	for _, todoData := range todos {
+		u.invalidateTodoCache(todoData)
+		workspaces[todoData.WorkspaceID] = true
+	}
+	for workspaceID := range workspaces {
+		u.invalidateTodoCaches(workspaceID, touched[workspaceID])
+		u.invalidateListCache(workspaceID)
+	}
+
+	return response, nil
+}
+
+// bulkOperation applies a single bulk item and returns the todo it produced or deleted with its status code, along
+// with the ids of the other todos whose cached copies changed with it
+func (u *TodoUsecaseImpl) bulkOperation(ctx context.Context, tx *gorm.DB, operation *model.TodoBulkOperation) (*entity.Todo, []string, int, error) {
+	if err := u.Validate.Struct(operation); err != nil {
+		return nil, nil, 0, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if operation.Op != model.TodoBulkOpCreate && operation.ID == nil {
+		return nil, nil, 0, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	switch operation.Op {
+	case model.TodoBulkOpCreate:
+		if operation.Create == nil {
+			return nil, nil, 0, errors.New(http.StatusText(http.StatusBadRequest))
+		}
+		todoData, err := u.create(ctx, tx, operation.Create)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		return todoData, parentIDs(todoData, nil), http.StatusCreated, nil
+	case model.TodoBulkOpUpdate:
+		if operation.Update == nil {
+			return nil, nil, 0, errors.New(http.StatusText(http.StatusBadRequest))
+		}
+		todoData, _, related, err := u.update(ctx, tx, &model.TodoUpdateIDRequest{ID: *operation.ID}, operation.Update)
+		return todoData, related, http.StatusOK, err
+	case model.TodoBulkOpComplete:
+		done := true
+		todoData, _, related, err := u.update(ctx, tx, &model.TodoUpdateIDRequest{ID: *operation.ID}, &model.TodoUpdateRequest{
+			Done:    &done,
+			Cascade: &operation.Cascade,
+		})
+		return todoData, related, http.StatusOK, err
+	case model.TodoBulkOpDelete:
+		todoData, related, _, err := u.delete(ctx, tx, &model.TodoDeleteRequest{ID: *operation.ID, Cascade: operation.Cascade})
+		return todoData, related, http.StatusNoContent, err
+	case model.TodoBulkOpMove:
+		todoData, related, err := u.move(ctx, tx, *operation.ID, operation.ParentID, operation.ProjectID)
+		return todoData, related, http.StatusOK, err
+	}
+
+	return nil, nil, 0, errors.New(http.StatusText(http.StatusBadRequest))
+}
+
+// move places a todo under another todo, or at the top level of a project or the inbox, and also returns the parents
+// it left and joined
+func (u *TodoUsecaseImpl) move(ctx context.Context, tx *gorm.DB, id string, parentID, projectID *string) (*entity.Todo, []string, error) {
+	if (parentID == nil) == (projectID == nil) {
+		return nil, nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, todoData, id); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleEditor); err != nil {
+		return nil, nil, err
+	}
+
+	before := converter.TodoToSnapshot(todoData)
+
+	if parentID != nil {
+		if err := u.checkParent(tx, todoData, *parentID); err != nil {
+			return nil, nil, err
+		}
+
+		parent := &entity.Todo{}
+		if err := u.TodoRepository.GetByID(tx, parent, *parentID); err != nil {
+			u.Log.Errorf("failed to get parent todo: %v", err)
+			return nil, nil, errors.New(http.StatusText(http.StatusNotFound))
+		}
+
+		// Subtasks live in the project of their parent
+		todoData.ParentID = &parent.ID
+		todoData.ProjectID = parent.ProjectID
+	} else {
+		todoData.ParentID = nil
+		todoData.ProjectID = nil
+		if *projectID != model.TodoProjectInbox {
+			if err := u.checkProject(tx, todoData.UserID, *projectID); err != nil {
+				return nil, nil, err
+			}
+			todoData.ProjectID = projectID
+		}
+	}
+
+	if err := u.appendToList(tx, todoData, before); err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.TodoRepository.Update(tx, todoData); err != nil {
+		u.Log.Errorf("failed to move todo: %v", err)
+		if errors.Is(err, todo.ErrStaleVersion) {
+			return nil, nil, errors.New(http.StatusText(http.StatusConflict))
+		}
+		return nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.record(ctx, tx, model.TodoActivityUpdated, todoData, before); err != nil {
+		return nil, nil, err
+	}
+
+	return todoData, parentIDs(todoData, before), nil
+}
+
+// bulkErrorStatus maps the status text errors of this usecase back to their code
+func bulkErrorStatus(err error) int {
+	for _, code := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed} {
+		if err.Error() == http.StatusText(code) {
+			return code
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+func (u *TodoUsecaseImpl) Skip(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, todoData, request.ID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleEditor); err != nil {
+		return nil, err
+	}
+
+	if todoData.Recurrence == nil || todoData.Done {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	before := converter.TodoToSnapshot(todoData)
+
+	due, ok, err := u.occurrenceAfter(todoData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skipping the last occurrence would leave nothing to move to, the series has to be ended instead
+	if !ok {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData.StartAt = shiftStart(todoData, due)
+	todoData.DueAt = &due
+
+	if err := u.TodoRepository.Update(tx, todoData); err != nil {
+		u.Log.Errorf("failed to skip occurrence: %v", err)
+		if errors.Is(err, todo.ErrStaleVersion) {
+			return nil, errors.New(http.StatusText(http.StatusConflict))
+		}
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.record(ctx, tx, model.TodoActivityUpdated, todoData, before); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(todoData)
+	u.invalidateListCache(todoData.WorkspaceID)
+
+	return converter.TodoToResponse(todoData, false), nil
+}
+
+func (u *TodoUsecaseImpl) EndSeries(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, todoData, request.ID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleEditor); err != nil {
+		return nil, err
+	}
+
+	if todoData.Recurrence == nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	before := converter.TodoToSnapshot(todoData)
+
+	// The todo stays as a one-off, the series id keeps it grouped with the earlier occurrences
+	clearRecurrence(todoData)
+
+	if err := u.TodoRepository.Update(tx, todoData); err != nil {
+		u.Log.Errorf("failed to end series: %v", err)
+		if errors.Is(err, todo.ErrStaleVersion) {
+			return nil, errors.New(http.StatusText(http.StatusConflict))
+		}
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.record(ctx, tx, model.TodoActivityUpdated, todoData, before); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(todoData)
+	u.invalidateListCache(todoData.WorkspaceID)
+
+	return converter.TodoToResponse(todoData, false), nil
+}
+
+func (u *TodoUsecaseImpl) Delete(ctx context.Context, request *model.TodoDeleteRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	todoData, related, files, err := u.delete(ctx, tx, request)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	u.invalidateTodoCache(todoData)
+	u.invalidateTodoCaches(todoData.WorkspaceID, related)
+	u.invalidateListCache(todoData.WorkspaceID)
+	u.removeAttachmentFiles(ctx, files)
+
+	return true, nil
+}
+
+// delete moves a todo to the trash, or purges it, within the caller's transaction and returns the todo with the ids of
+// the other todos whose cached copies changed with it. A purge also returns the storage keys of the attachments that
+// went with it, their files are removed once the transaction commits.
+func (u *TodoUsecaseImpl) delete(ctx context.Context, tx *gorm.DB, request *model.TodoDeleteRequest) (*entity.Todo, []string, []string, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	getTodo := u.TodoRepository.GetByID
+	if request.Permanent {
+		getTodo = u.TodoRepository.GetByIDWithTrashed
+	}
+
+	todoData := &entity.Todo{}
+	if err := getTodo(tx, todoData, request.ID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleOwner); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The subtasks either go down with the todo or move up to its parent
+	var ids []string
+	var err error
+	if request.Cascade {
+		if ids, err = u.TodoRepository.GetDescendantIDs(tx, todoData.ID); err != nil {
+			u.Log.Errorf("failed to get subtasks: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	} else if ids, err = u.TodoRepository.ReparentChildren(tx, todoData.ID, todoData.ParentID); err != nil {
+		u.Log.Errorf("failed to move subtasks: %v", err)
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+	related := append(parentIDs(todoData, nil), ids...)
+
+	if request.Permanent {
+		files, err := u.AttachmentRepository.GetStorageKeysByTodoID(tx, todoData.ID)
+		if err != nil {
+			u.Log.Errorf("failed to get attachments: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+
+		// Purging takes the subtasks that did not move up with it
+		if err := u.TodoRepository.Purge(tx, todoData); err != nil {
+			u.Log.Errorf("failed to purge todo: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+
+		return todoData, related, files, nil
+	}
+
+	if request.Cascade {
+		if err := u.TodoRepository.DeleteByIDs(tx, ids); err != nil {
+			u.Log.Errorf("failed to delete subtasks: %v", err)
+			return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	if err := u.TodoRepository.Delete(tx, todoData); err != nil {
+		u.Log.Errorf("failed to delete todo: %v", err)
+		return nil, nil, nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	// A purge takes the history with it, only moving to the trash is recorded
+	if err := u.record(ctx, tx, model.TodoActivityDeleted, todoData, converter.TodoToSnapshot(todoData)); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return todoData, related, nil, nil
+}
+
+func (u *TodoUsecaseImpl) Get(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	// Todos are cached per workspace, a todo is never served to a request in another workspace. The cache keeps
+	// the todo rather than a response, so every caller is authorized against it whether it was cached or not.
+	workspace, _ := tenant.FromContext(ctx)
+	key := fmt.Sprintf("todos:get:%s:%s", workspace.ID, request.ID)
+	var todoData *entity.Todo
+	err := u.Cache.Get(key, &todoData)
+	if err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+		u.Log.Errorf("failed to get data from cache: %v", err)
+	}
+
+	db := u.DB.WithContext(ctx)
+	cached := todoData != nil
+	if !cached {
+		todoData = &entity.Todo{}
+		if err := u.TodoRepository.GetByID(db, todoData, request.ID); err != nil {
+			u.Log.Errorf("failed to get todo: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusNotFound))
+		}
+	}
+
+	if err := u.authorize(ctx, db, todoData, model.ShareRoleViewer); err != nil {
+		return nil, err
+	}
+
+	if !cached {
+		if err := u.Cache.Set(key, todoData, 5*time.Minute); err != nil {
+			u.Log.Errorf("failed to set data to cache: %v", err)
+		}
+	}
+
+	return converter.TodoToResponse(todoData, u.helper.IsAdmin(ctx)), nil
+}
+
+func (u *TodoUsecaseImpl) GetTrash(ctx context.Context, request *model.TodoTrashRequest) (*model.Response[[]*model.TodoResponse], error) {
 	if err := u.Validate.Struct(request); err != nil {
 		return nil, errors.New(http.StatusText(http.StatusBadRequest))
 	}
 
-	claims, err := u.helper.GetJWTClaims(ctx)
-	if err != nil {
-		u.Log.Errorf("failed to get JWT claims: %v", err)
-		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	isAdmin := u.helper.IsAdmin(ctx)
+	userID := claims.UserID
+
+	opts := model.TodoTrashOptions{
+		Page:    request.Page,
+		Size:    request.Size,
+		IsAdmin: isAdmin,
+	}
+
+	// If not admin, always filter by user's ID
+	if !isAdmin {
+		opts.UserID = &userID
 	}
 
-	todoData := &entity.Todo{
-		ID:     uuid.NewString(),
-		Title:  request.Title,
-		Done:   false,
-		UserID: claims.UserID,
+	if u.TrashRetention > 0 {
+		cutoff := time.Now().Add(-u.TrashRetention)
+		opts.DeletedAfter = &cutoff
 	}
 
-	if err := u.TodoRepository.Create(tx, todoData); err != nil {
-		u.Log.Errorf("failed to create todo: %v", err)
-		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	// Ensure valid pagination parameters
+	if request.Size <= 0 {
+		request.Size = 10 // Default page size
+	}
+	if request.Page <= 0 {
+		request.Page = 1 // Default page number
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		u.Log.Errorf("failed to commit transaction: %v", err)
+	var todos []entity.Todo
+	totalItems, err := u.TodoRepository.GetTrashed(u.DB.WithContext(ctx), &todos, opts)
+	if err != nil {
+		u.Log.Errorf("failed to get trashed todos: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	u.invalidateUserListCache(claims.UserID)
+	if totalItems == 0 || len(todos) == 0 {
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
 
-	return converter.TodoToResponse(todoData, false), nil
+	return converter.TodosToPaginatedResponse(todos, totalItems, request.Page, request.Size, isAdmin), nil
 }
 
-func (u *TodoUsecaseImpl) Update(ctx context.Context, id *model.TodoUpdateIDRequest, request *model.TodoUpdateRequest) (*model.TodoResponse, error) {
+// Restore brings a todo back from the trash together with the trashed subtasks below it
+func (u *TodoUsecaseImpl) Restore(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error) {
 	tx := u.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
-	if request.Done == nil && request.Title == nil {
+	if err := u.Validate.Struct(request); err != nil {
 		return nil, errors.New(http.StatusText(http.StatusBadRequest))
 	}
 
-	if err := u.Validate.Struct(id); err != nil {
-		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	todoData := &entity.Todo{}
+	if err := u.TodoRepository.GetByIDWithTrashed(tx, todoData, request.ID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
 	}
 
-	if err := u.Validate.Struct(request); err != nil {
+	if err := u.authorize(ctx, tx, todoData, model.ShareRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if !todoData.DeletedAt.Valid {
 		return nil, errors.New(http.StatusText(http.StatusBadRequest))
 	}
 
-	todoData := &entity.Todo{}
-	if err := u.TodoRepository.GetByID(tx, todoData, id.ID); err != nil {
-		u.Log.Errorf("failed to get todo: %v", err)
-		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	if u.TrashRetention > 0 && todoData.DeletedAt.Time.Before(time.Now().Add(-u.TrashRetention)) {
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
 	}
 
-	if err := u.helper.VerifyOwnership(ctx, todoData.UserID); err != nil {
-		u.Log.Errorf("unauthorized access attempt: %v", err)
-		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	before := converter.TodoToSnapshot(todoData)
+
+	ids, err := u.TodoRepository.GetTrashedDescendantIDs(tx, todoData.ID)
+	if err != nil {
+		u.Log.Errorf("failed to get trashed subtasks: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	if request.Title != nil {
-		todoData.Title = *request.Title
+	if err := u.TodoRepository.RestoreByIDs(tx, append(ids, todoData.ID)); err != nil {
+		u.Log.Errorf("failed to restore todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
-	if request.Done != nil {
-		todoData.Done = *request.Done
+	todoData.DeletedAt = gorm.DeletedAt{}
+	// Restoring bumped the version in the database already
+	todoData.Version++
+
+	// A parent or project that is still gone cannot take the todo back, it lands at the top level of the inbox
+	if todoData.ParentID != nil {
+		if err := u.TodoRepository.GetByID(tx, &entity.Todo{}, *todoData.ParentID); err != nil {
+			todoData.ParentID = nil
+		}
+	}
+	if todoData.ProjectID != nil {
+		if err := u.ProjectRepository.GetByID(tx, &entity.Project{}, *todoData.ProjectID); err != nil {
+			todoData.ProjectID = nil
+		}
 	}
 
 	if err := u.TodoRepository.Update(tx, todoData); err != nil {
-		u.Log.Errorf("failed to update todo: %v", err)
+		u.Log.Errorf("failed to update restored todo: %v", err)
+		if errors.Is(err, todo.ErrStaleVersion) {
+			return nil, errors.New(http.StatusText(http.StatusConflict))
+		}
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.TodoRepository.GetByID(tx, todoData, todoData.ID); err != nil {
+		u.Log.Errorf("failed to get restored todo: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
+	if err := u.record(ctx, tx, model.TodoActivityRestored, todoData, before); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		u.Log.Errorf("failed to commit transaction: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
+	u.invalidateTodoCache(todoData)
+	u.invalidateTodoCaches(todoData.WorkspaceID, append(ids, parentIDs(todoData, before)...))
+	u.invalidateListCache(todoData.WorkspaceID)
+
 	return converter.TodoToResponse(todoData, false), nil
 }
 
-func (u *TodoUsecaseImpl) Delete(ctx context.Context, request *model.TodoDeleteRequest) (bool, error) {
-	tx := u.DB.WithContext(ctx).Begin()
-	defer tx.Rollback()
-
-	if err := u.Validate.Struct(request); err != nil {
-		return false, errors.New(http.StatusText(http.StatusBadRequest))
+// PurgeTrash hard-deletes the todos that stayed in the trash longer than the retention window
+func (u *TodoUsecaseImpl) PurgeTrash(ctx context.Context) (int64, error) {
+	if u.TrashRetention <= 0 {
+		return 0, nil
 	}
 
-	todoData := &entity.Todo{}
-	if err := u.TodoRepository.GetByID(tx, todoData, request.ID); err != nil {
-		u.Log.Errorf("failed to get todo: %v", err)
-		return false, errors.New(http.StatusText(http.StatusNotFound))
-	}
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
 
-	if err := u.helper.VerifyOwnership(ctx, todoData.UserID); err != nil {
-		u.Log.Errorf("unauthorized access attempt: %v", err)
-		return false, errors.New(http.StatusText(http.StatusForbidden))
+	before := time.Now().Add(-u.TrashRetention)
+	files, err := u.AttachmentRepository.GetStorageKeysDeletedBefore(tx, before)
+	if err != nil {
+		u.Log.Errorf("failed to get attachments: %v", err)
+		return 0, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	if err := u.TodoRepository.Delete(tx, todoData); err != nil {
-		u.Log.Errorf("failed to delete todo: %v", err)
-		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	purged, err := u.TodoRepository.PurgeDeletedBefore(tx, before)
+	if err != nil {
+		u.Log.Errorf("failed to purge trash: %v", err)
+		return 0, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		u.Log.Errorf("failed to commit transaction: %v", err)
-		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+		return 0, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	return true, nil
+	u.removeAttachmentFiles(ctx, files)
+
+	return purged, nil
 }
 
-func (u *TodoUsecaseImpl) Get(ctx context.Context, request *model.TodoGetRequest) (*model.TodoResponse, error) {
+func (u *TodoUsecaseImpl) GetChildren(ctx context.Context, request *model.TodoGetRequest) (*model.Response[[]*model.TodoResponse], error) {
 	if err := u.Validate.Struct(request); err != nil {
 		return nil, errors.New(http.StatusText(http.StatusBadRequest))
 	}
 
-	key := fmt.Sprintf("todos:get:%s", request.ID)
-	var data *model.TodoResponse
-	err := u.Cache.Get(key, &data)
-	if err != nil && !errors.Is(err, cache.ErrCacheMiss) {
-		u.Log.Errorf("failed to get data from cache: %v", err)
-	}
-
-	isAdmin := u.helper.IsAdmin(ctx)
-
-	if data == nil {
-		tx := u.DB.WithContext(ctx).Begin()
-		defer tx.Rollback()
-
-		todoData := &entity.Todo{}
-		if err := u.TodoRepository.GetByID(tx, todoData, request.ID); err != nil {
-			u.Log.Errorf("failed to get todo: %v", err)
-			return nil, errors.New(http.StatusText(http.StatusNotFound))
-		}
-
-		if err := u.helper.VerifyOwnership(ctx, todoData.UserID); err != nil {
-			u.Log.Errorf("unauthorized access attempt: %v", err)
-			return nil, errors.New(http.StatusText(http.StatusForbidden))
-		}
+	db := u.DB.WithContext(ctx)
 
-		response := converter.TodoToResponse(todoData, isAdmin)
+	parent := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(db, parent, request.ID); err != nil {
+		u.Log.Errorf("failed to get todo: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
 
-		if err := u.Cache.Set(key, response, 5*time.Minute); err != nil {
-			u.Log.Errorf("failed to set data to cache: %v", err)
-		}
+	if err := u.authorize(ctx, db, parent, model.ShareRoleViewer); err != nil {
+		return nil, err
+	}
 
-		return response, nil
+	var children []entity.Todo
+	if err := u.TodoRepository.GetChildren(db, &children, parent.ID); err != nil {
+		u.Log.Errorf("failed to get subtasks: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	return data, nil
+	return model.NewResponse(converter.TodosToResponses(children, u.helper.IsAdmin(ctx)), nil), nil
 }
 
 func (u *TodoUsecaseImpl) Search(ctx context.Context, request *model.TodoSearchRequest) (*model.Response[[]*model.TodoResponse], error) {
@@ -209,10 +1035,38 @@ func (u *TodoUsecaseImpl) Search(ctx context.Context, request *model.TodoSearchR
 	userID := claims.UserID
 
 	opts := model.TodoQueryOptions{
-		Page:    request.Page,
-		Size:    request.Size,
-		IsAdmin: isAdmin,
-		Title:   &request.Title,
+		Page:      request.Page,
+		Size:      request.Size,
+		IsAdmin:   isAdmin,
+		Tags:      splitTagNames(request.Tags),
+		TagMode:   model.TodoTagModeAny,
+		ProjectID: request.ProjectID,
+		After:     request.After,
+		Before:    request.Before,
+	}
+
+	if request.TagMode != nil {
+		opts.TagMode = *request.TagMode
+	}
+
+	q := request.Q
+	if q == "" {
+		q = request.Title
+	}
+	if q != "" {
+		query, err := tsquery.Parse(q)
+		if err != nil {
+			return nil, errors.New(http.StatusText(http.StatusBadRequest))
+		}
+		opts.Query = &query
+	}
+
+	if request.Filter != "" {
+		node, err := filter.Parse(request.Filter)
+		if err != nil {
+			return nil, err
+		}
+		opts.Filter = node
 	}
 
 	// If not admin, always filter by user's ID
@@ -220,6 +1074,11 @@ func (u *TodoUsecaseImpl) Search(ctx context.Context, request *model.TodoSearchR
 		opts.UserID = &userID
 	}
 
+	if workspace, ok := tenant.FromContext(ctx); ok {
+		opts.WorkspaceID = workspace.ID
+		opts.WorkspaceWide = model.WorkspaceShareRole(workspace.Role) != ""
+	}
+
 	// Ensure valid pagination parameters
 	if request.Size <= 0 {
 		request.Size = 10 // Default page size
@@ -228,6 +1087,17 @@ func (u *TodoUsecaseImpl) Search(ctx context.Context, request *model.TodoSearchR
 		request.Page = 1 // Default page number
 	}
 
+	// Paging by cursor skips the count unless asked for, page numbers keep it by default
+	byCursor := request.After != nil || request.Before != nil
+	opts.WithTotal = !byCursor
+	if request.Total != nil {
+		opts.WithTotal = *request.Total
+	}
+	page := request.Page
+	if byCursor {
+		page = 0
+	}
+
 	// Ensure sort parameter
 	if request.Sort != nil && request.Order != nil {
 		opts.Sort = *request.Sort
@@ -237,27 +1107,37 @@ func (u *TodoUsecaseImpl) Search(ctx context.Context, request *model.TodoSearchR
 	// Try to get cached data
 	cacheKey := u.helper.BuildCacheKey(opts)
 	var cachedResponse *model.Response[[]*model.TodoResponse]
-	if err := u.Cache.Get(cacheKey, &cachedResponse); err == nil {
-		return cachedResponse, nil
+	if cacheKey != "" {
+		if err := u.Cache.Get(cacheKey, &cachedResponse); err == nil {
+			return cachedResponse, nil
+		}
 	}
 
 	// If cache miss, get from database
 	var todos []entity.Todo
-	totalItems, err := u.TodoRepository.GetPaginated(u.DB, &todos, opts)
+	todoPage, err := u.TodoRepository.GetPaginated(u.DB.WithContext(ctx), &todos, opts)
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalidCursor) {
+			return nil, errors.New(http.StatusText(http.StatusBadRequest))
+		}
+		if errors.Is(err, filter.ErrInvalidFilter) {
+			return nil, err
+		}
 		u.Log.Errorf("failed to get todos: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	if totalItems == 0 || len(todos) == 0 {
+	if len(todos) == 0 {
 		return nil, errors.New(http.StatusText(http.StatusNotFound))
 	}
 
-	response := converter.TodosToPaginatedResponse(todos, totalItems, request.Page, request.Size, isAdmin)
+	response := converter.TodosToPageResponse(todos, todoPage, page, request.Size, isAdmin)
 
 	// Cache the response
-	if err := u.Cache.Set(cacheKey, response, 5*time.Minute); err != nil {
-		u.Log.Errorf("failed to cache response: %v", err)
+	if cacheKey != "" {
+		if err := u.Cache.Set(cacheKey, response, 5*time.Minute); err != nil {
+			u.Log.Errorf("failed to cache response: %v", err)
+		}
 	}
 
 	return response, nil
@@ -278,9 +1158,37 @@ func (u *TodoUsecaseImpl) GetAll(ctx context.Context, request *model.TodoGetAllR
 	userID := claims.UserID
 
 	opts := model.TodoQueryOptions{
-		Page:    request.Page,
-		Size:    request.Size,
-		IsAdmin: isAdmin,
+		Page:      request.Page,
+		Size:      request.Size,
+		IsAdmin:   isAdmin,
+		Tags:      splitTagNames(request.Tags),
+		TagMode:   model.TodoTagModeAny,
+		ProjectID: request.ProjectID,
+		After:     request.After,
+		Before:    request.Before,
+		DueBefore: request.DueBefore,
+		DueAfter:  request.DueAfter,
+	}
+
+	if request.TagMode != nil {
+		opts.TagMode = *request.TagMode
+	}
+
+	if request.Priority != nil {
+		level := converter.PriorityToLevel(*request.Priority)
+		opts.Priority = &level
+	}
+
+	if request.Due != nil {
+		opts.Due = *request.Due
+	}
+
+	if request.Filter != "" {
+		node, err := filter.Parse(request.Filter)
+		if err != nil {
+			return nil, err
+		}
+		opts.Filter = node
 	}
 
 	// If not admin, always filter by user's ID
@@ -288,6 +1196,11 @@ func (u *TodoUsecaseImpl) GetAll(ctx context.Context, request *model.TodoGetAllR
 		opts.UserID = &userID
 	}
 
+	if workspace, ok := tenant.FromContext(ctx); ok {
+		opts.WorkspaceID = workspace.ID
+		opts.WorkspaceWide = model.WorkspaceShareRole(workspace.Role) != ""
+	}
+
 	// Ensure valid pagination parameters
 	if request.Size <= 0 {
 		request.Size = 10 // Default page size
@@ -296,6 +1209,17 @@ func (u *TodoUsecaseImpl) GetAll(ctx context.Context, request *model.TodoGetAllR
 		request.Page = 1 // Default page number
 	}
 
+	// Paging by cursor skips the count unless asked for, page numbers keep it by default
+	byCursor := request.After != nil || request.Before != nil
+	opts.WithTotal = !byCursor
+	if request.Total != nil {
+		opts.WithTotal = *request.Total
+	}
+	page := request.Page
+	if byCursor {
+		page = 0
+	}
+
 	// Ensure sort parameter
 	if request.Sort != nil && request.Order != nil {
 		opts.Sort = *request.Sort
@@ -305,39 +1229,341 @@ func (u *TodoUsecaseImpl) GetAll(ctx context.Context, request *model.TodoGetAllR
 	// Try to get cached data
 	cacheKey := u.helper.BuildCacheKey(opts)
 	var cachedResponse *model.Response[[]*model.TodoResponse]
-	if err := u.Cache.Get(cacheKey, &cachedResponse); err == nil {
-		return cachedResponse, nil
+	if cacheKey != "" {
+		if err := u.Cache.Get(cacheKey, &cachedResponse); err == nil {
+			return cachedResponse, nil
+		}
 	}
 
 	// If cache miss, get from database
 	var todos []entity.Todo
-	totalItems, err := u.TodoRepository.GetPaginated(u.DB, &todos, opts)
+	todoPage, err := u.TodoRepository.GetPaginated(u.DB.WithContext(ctx), &todos, opts)
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalidCursor) {
+			return nil, errors.New(http.StatusText(http.StatusBadRequest))
+		}
+		if errors.Is(err, filter.ErrInvalidFilter) {
+			return nil, err
+		}
 		u.Log.Errorf("failed to get todos: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	if totalItems == 0 || len(todos) == 0 {
+	if len(todos) == 0 {
 		return nil, errors.New(http.StatusText(http.StatusNotFound))
 	}
 
-	response := converter.TodosToPaginatedResponse(todos, totalItems, request.Page, request.Size, isAdmin)
+	response := converter.TodosToPageResponse(todos, todoPage, page, request.Size, isAdmin)
 
 	// Cache the response
-	if err := u.Cache.Set(cacheKey, response, 5*time.Minute); err != nil {
-		u.Log.Errorf("failed to cache response: %v", err)
+	if cacheKey != "" {
+		if err := u.Cache.Set(cacheKey, response, 5*time.Minute); err != nil {
+			u.Log.Errorf("failed to cache response: %v", err)
+		}
 	}
 
 	return response, nil
 }
 
-func (u *TodoUsecaseImpl) invalidateUserListCache(userID string) {
-	if err := u.Cache.Delete(fmt.Sprintf("todos:user:%s:list:metadata", userID)); err != nil {
-		u.Log.Errorf("failed to delete user metadata cache: %v", err)
+// validSchedule reports whether a todo starts no later than it is due
+func validSchedule(todo *entity.Todo) bool {
+	if todo.StartAt == nil || todo.DueAt == nil {
+		return true
+	}
+	return !todo.StartAt.After(*todo.DueAt)
+}
+
+// setRecurrence validates the rule and (re)starts the series at the todo's due date
+func (u *TodoUsecaseImpl) setRecurrence(todoData *entity.Todo, recurrence string, timezone *string) error {
+	if todoData.DueAt == nil {
+		return errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	rule, err := rrule.Parse(recurrence)
+	if err != nil {
+		u.Log.Errorf("invalid recurrence rule: %v", err)
+		return errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	normalized := rule.String()
+	start := *todoData.DueAt
+	todoData.Recurrence = &normalized
+	todoData.RecurrenceStart = &start
+
+	if timezone != nil {
+		todoData.RecurrenceTZ = timezone
+	} else if todoData.RecurrenceTZ == nil {
+		utc := time.UTC.String()
+		todoData.RecurrenceTZ = &utc
+	}
+
+	if todoData.SeriesID == nil {
+		seriesID := uuid.NewString()
+		todoData.SeriesID = &seriesID
+	}
+
+	return nil
+}
+
+// occurrenceAfter expands the todo's rule to the first occurrence after its current due date
+func (u *TodoUsecaseImpl) occurrenceAfter(todoData *entity.Todo) (time.Time, bool, error) {
+	rule, err := rrule.Parse(*todoData.Recurrence)
+	if err != nil {
+		u.Log.Errorf("invalid stored recurrence rule: %v", err)
+		return time.Time{}, false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	loc := time.UTC
+	if todoData.RecurrenceTZ != nil {
+		if loc, err = time.LoadLocation(*todoData.RecurrenceTZ); err != nil {
+			u.Log.Errorf("invalid stored recurrence timezone: %v", err)
+			return time.Time{}, false, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	start, after := todoData.RecurrenceStart, todoData.DueAt
+	if after == nil {
+		return time.Time{}, false, nil
+	}
+	if start == nil {
+		start = after
+	}
+
+	next, ok := rule.Next(start.In(loc), *after)
+	return next, ok, nil
+}
+
+// nextOccurrence builds the occurrence following a completed one and hands the rule over to it.
+// It returns nil when the series has ended.
+func (u *TodoUsecaseImpl) nextOccurrence(todoData *entity.Todo) (*entity.Todo, error) {
+	due, ok, err := u.occurrenceAfter(todoData)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *entity.Todo
+	if ok {
+		next = &entity.Todo{
+			ID:              uuid.NewString(),
+			Title:           todoData.Title,
+			Description:     todoData.Description,
+			Priority:        todoData.Priority,
+			DueAt:           &due,
+			StartAt:         shiftStart(todoData, due),
+			ParentID:        todoData.ParentID,
+			ProjectID:       todoData.ProjectID,
+			Recurrence:      todoData.Recurrence,
+			RecurrenceStart: todoData.RecurrenceStart,
+			RecurrenceTZ:    todoData.RecurrenceTZ,
+			SeriesID:        todoData.SeriesID,
+			UserID:          todoData.UserID,
+			Tags:            todoData.Tags,
+		}
+	}
+
+	clearRecurrence(todoData)
+
+	return next, nil
+}
+
+// shiftStart keeps the lead time between start and due when a todo moves to a new due date
+func shiftStart(todoData *entity.Todo, due time.Time) *time.Time {
+	if todoData.StartAt == nil || todoData.DueAt == nil {
+		return todoData.StartAt
+	}
+
+	start := due.Add(todoData.StartAt.Sub(*todoData.DueAt))
+	return &start
+}
+
+func clearRecurrence(todoData *entity.Todo) {
+	todoData.Recurrence = nil
+	todoData.RecurrenceStart = nil
+	todoData.RecurrenceTZ = nil
+}
+
+// authorize checks the caller holds at least the role on the todo, as its owner, an admin or through a share
+func (u *TodoUsecaseImpl) authorize(ctx context.Context, db *gorm.DB, todoData *entity.Todo, role string) error {
+	err := u.helper.VerifyAccess(ctx, todoData.UserID, role, func(userID string) (string, error) {
+		return u.ShareRepository.GetTodoRole(db, userID, todoData.ID)
+	})
+	if err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	return nil
+}
+
+// checkParent verifies the todo can move under the parent without changing owner or creating a cycle
+func (u *TodoUsecaseImpl) checkParent(tx *gorm.DB, todoData *entity.Todo, parentID string) error {
+	if parentID == todoData.ID {
+		return errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	parent := &entity.Todo{}
+	if err := u.TodoRepository.GetByID(tx, parent, parentID); err != nil {
+		u.Log.Errorf("failed to get parent todo: %v", err)
+		return errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if parent.UserID != todoData.UserID {
+		u.Log.Errorf("parent todo belongs to another user: %v", parentID)
+		return errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	descendants, err := u.TodoRepository.GetDescendantIDs(tx, todoData.ID)
+	if err != nil {
+		u.Log.Errorf("failed to get subtasks: %v", err)
+		return errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	for _, id := range descendants {
+		if id == parentID {
+			return errors.New(http.StatusText(http.StatusBadRequest))
+		}
+	}
+
+	return nil
+}
+
+// checkProject verifies the project belongs to the todo owner and is not archived
+func (u *TodoUsecaseImpl) checkProject(tx *gorm.DB, userID, projectID string) error {
+	projectData := &entity.Project{}
+	if err := u.ProjectRepository.GetByID(tx, projectData, projectID); err != nil {
+		u.Log.Errorf("failed to get project: %v", err)
+		return errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if projectData.UserID != userID || projectData.Archived {
+		u.Log.Errorf("project does not accept todos: %v", projectID)
+		return errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	return nil
+}
+
+// projectOwner returns who a new todo in the project belongs to, todos added through a share go to the project's owner
+func (u *TodoUsecaseImpl) projectOwner(ctx context.Context, tx *gorm.DB, projectID string) (string, error) {
+	projectData := &entity.Project{}
+	if err := u.ProjectRepository.GetByID(tx, projectData, projectID); err != nil {
+		u.Log.Errorf("failed to get project: %v", err)
+		return "", errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	err := u.helper.VerifyAccess(ctx, projectData.UserID, model.ShareRoleEditor, func(userID string) (string, error) {
+		return u.ShareRepository.GetProjectRole(tx, userID, projectData.ID)
+	})
+	if err != nil {
+		u.Log.Errorf("unauthorized access attempt: %v", err)
+		return "", errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	return projectData.UserID, nil
+}
+
+// resolveTags returns the user's tags with the given names, creating the missing ones
+func (u *TodoUsecaseImpl) resolveTags(tx *gorm.DB, userID string, names []string) ([]entity.Tag, error) {
+	names = splitTagNames(names)
+	if len(names) == 0 {
+		return []entity.Tag{}, nil
+	}
+
+	var tags []entity.Tag
+	if err := u.TagRepository.GetByNames(tx, &tags, userID, names); err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		existing[t.Name] = true
+	}
+
+	for _, name := range names {
+		if existing[name] {
+			continue
+		}
+
+		newTag := entity.Tag{
+			ID:     uuid.NewString(),
+			Name:   name,
+			UserID: userID,
+		}
+		if err := u.TagRepository.Create(tx, &newTag); err != nil {
+			return nil, err
+		}
+		tags = append(tags, newTag)
+	}
+
+	return tags, nil
+}
+
+// removeAttachmentFiles deletes the stored files of purged attachments, a failure only leaves an orphaned file behind
+func (u *TodoUsecaseImpl) removeAttachmentFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := u.Storage.Delete(ctx, key); err != nil {
+			u.Log.Errorf("failed to delete attachment file %s: %v", key, err)
+		}
+	}
+}
+
+// nonEmpty treats an empty string as no value
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+// splitTagNames accepts both repeated values and comma separated lists, and drops blanks and duplicates
+func splitTagNames(values []string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// invalidateTodoCache drops the copy of a todo that Get serves, so it never hands out an old version
+func (u *TodoUsecaseImpl) invalidateTodoCache(todoData *entity.Todo) {
+	if err := u.Cache.Delete(fmt.Sprintf("todos:get:%s:%s", todoData.WorkspaceID, todoData.ID)); err != nil {
+		u.Log.Errorf("failed to delete todo cache: %v", err)
 	}
+}
+
+// invalidateTodoCaches drops the cached copies of the other todos a write changed on the way, the subtasks it
+// cascaded to and the parents whose subtask counts moved
+func (u *TodoUsecaseImpl) invalidateTodoCaches(workspaceID string, ids []string) {
+	for _, id := range ids {
+		u.invalidateTodoCache(&entity.Todo{ID: id, WorkspaceID: workspaceID})
+	}
+}
+
+// parentIDs lists the parent a todo had before a write, which is nil for a new todo, and the one it has after it
+func parentIDs(todoData *entity.Todo, before *model.TodoSnapshot) []string {
+	var ids []string
+	if before != nil && before.ParentID != nil {
+		ids = append(ids, *before.ParentID)
+	}
+	if todoData.ParentID != nil && (before == nil || !equalPointers(todoData.ParentID, before.ParentID)) {
+		ids = append(ids, *todoData.ParentID)
+	}
+	return ids
+}
 
-	pattern := fmt.Sprintf("todos:user:%s:list:data:*", userID)
-	if err := u.Cache.DeletePattern(pattern); err != nil {
-		u.Log.Errorf("failed to delete user data caches: %v", err)
+// invalidateListCache drops every list cached for a workspace, whoever it was for and whatever page, sort or
+// filter it had. Shares and workspace-wide lists show a todo to more users than its owner, so one write can change
+// the lists of any member.
+func (u *TodoUsecaseImpl) invalidateListCache(workspaceID string) {
+	if err := u.Cache.DeletePattern(u.helper.ListCachePattern(workspaceID)); err != nil {
+		u.Log.Errorf("failed to delete list caches: %v", err)
 	}
 }
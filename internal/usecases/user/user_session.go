@@ -0,0 +1,151 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"gorm.io/gorm"
+	"net/http"
+	"time"
+)
+
+// Logout ends the session of the calling token and denies the token itself for the rest of its lifetime, the
+// session may be revoked already and logging out twice is not an error
+func (u *UserUsecaseImpl) Logout(ctx context.Context) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return false, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	now := time.Now()
+	if claims.SessionID != "" {
+		sessionData := &entity.Session{}
+		err := u.SessionRepository.GetActiveByID(tx, sessionData, claims.UserID, claims.SessionID)
+		switch {
+		case err == nil:
+			sessionData.RevokedAt = &now
+			if err := u.SessionRepository.Update(tx, sessionData); err != nil {
+				u.Log.Errorf("failed to revoke session: %v", err)
+				return false, errors.New(http.StatusText(http.StatusInternalServerError))
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			u.Log.Errorf("failed to get session: %v", err)
+			return false, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		if err := u.Denylist.Add(claims.ID, claims.ExpiresAt.Sub(now)); err != nil {
+			u.Log.Errorf("failed to deny access token: %v", err)
+			return false, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	return true, nil
+}
+
+// GetSessions lists the open sessions of the calling user, the one of the calling token is marked as current
+func (u *UserUsecaseImpl) GetSessions(ctx context.Context) (*model.Response[[]*model.SessionResponse], error) {
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	var sessions []entity.Session
+	if err := u.SessionRepository.GetActiveByUserID(u.DB.WithContext(ctx), &sessions, claims.UserID); err != nil {
+		u.Log.Errorf("failed to get sessions: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return model.NewResponse(converter.SessionsToResponses(sessions, claims.SessionID), nil), nil
+}
+
+// RevokeSession ends one session of the calling user, its refresh token stops working right away
+func (u *UserUsecaseImpl) RevokeSession(ctx context.Context, request *model.SessionDeleteRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return false, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	sessionData := &entity.Session{}
+	if err := u.SessionRepository.GetActiveByID(tx, sessionData, claims.UserID, request.ID); err != nil {
+		u.Log.Errorf("failed to get session: %v", err)
+		return false, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	now := time.Now()
+	sessionData.RevokedAt = &now
+	if err := u.SessionRepository.Update(tx, sessionData); err != nil {
+		u.Log.Errorf("failed to revoke session: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return true, nil
+}
+
+// RevokeSessions ends every session of a user, users may revoke their own and admins those of anyone
+func (u *UserUsecaseImpl) RevokeSessions(ctx context.Context, request *model.SessionRevokeRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return false, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	userID := claims.UserID
+	if request.UserID != "" && request.UserID != claims.UserID {
+		if !u.helper.IsAdmin(ctx) {
+			u.Log.Errorf("unauthorized access attempt: %s may not revoke sessions of %s", claims.UserID, request.UserID)
+			return false, errors.New(http.StatusText(http.StatusForbidden))
+		}
+
+		if err := u.UserRepository.GetByID(tx, &entity.User{}, request.UserID); err != nil {
+			u.Log.Errorf("failed to get user: %v", err)
+			return false, errors.New(http.StatusText(http.StatusNotFound))
+		}
+		userID = request.UserID
+	}
+
+	if err := u.SessionRepository.RevokeByUserID(tx, userID); err != nil {
+		u.Log.Errorf("failed to revoke sessions: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return true, nil
+}
@@ -8,5 +8,11 @@ import (
 type UserUsecase interface {
 	Create(ctx context.Context, request *model.RegisterRequest) (*model.UserResponse, error)
 	Login(ctx context.Context, request *model.LoginRequest) (*model.TokenResponse, error)
-	RefreshToken(request *model.RefreshTokenRequest) (*model.TokenResponse, error)
+	Verify(ctx context.Context, request *model.VerifyEmailRequest) (*model.UserResponse, error)
+	ResendVerification(ctx context.Context, request *model.ResendVerificationRequest) (bool, error)
+	RefreshToken(ctx context.Context, request *model.RefreshTokenRequest) (*model.TokenResponse, error)
+	Logout(ctx context.Context) (bool, error)
+	GetSessions(ctx context.Context) (*model.Response[[]*model.SessionResponse], error)
+	RevokeSession(ctx context.Context, request *model.SessionDeleteRequest) (bool, error)
+	RevokeSessions(ctx context.Context, request *model.SessionRevokeRequest) (bool, error)
 }
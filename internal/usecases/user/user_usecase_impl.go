@@ -8,29 +8,51 @@ import (
 	"github.com/savioruz/mikti-task/internal/domain/entity"
 	"github.com/savioruz/mikti-task/internal/domain/model"
 	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/helper"
 	"github.com/savioruz/mikti-task/internal/platform/jwt"
+	"github.com/savioruz/mikti-task/internal/platform/mailer"
+	"github.com/savioruz/mikti-task/internal/repositories/session"
 	"github.com/savioruz/mikti-task/internal/repositories/user"
+	"github.com/savioruz/mikti-task/internal/repositories/workspace"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"net/http"
+	"time"
 )
 
 type UserUsecaseImpl struct {
-	DB             *gorm.DB
-	Log            *logrus.Logger
-	Validate       *validator.Validate
-	UserRepository *user.UserRepositoryImpl
-	JWTService     jwt.JWTService
+	DB                  *gorm.DB
+	Log                 *logrus.Logger
+	Validate            *validator.Validate
+	UserRepository      *user.UserRepositoryImpl
+	WorkspaceRepository workspace.WorkspaceRepository
+	MemberRepository    workspace.MemberRepository
+	SessionRepository   session.SessionRepository
+	JWTService          jwt.JWTService
+	Denylist            jwt.Denylist
+	Mailer              mailer.Mailer
+	// RefreshExpiry is how long a session lasts without being refreshed
+	RefreshExpiry time.Duration
+	Verification  *VerificationConfig
+	helper        *helper.ContextHelper
 }
 
-func NewUserUsecaseImpl(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, userRepository *user.UserRepositoryImpl, jwtService jwt.JWTService) *UserUsecaseImpl {
+func NewUserUsecaseImpl(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, userRepository *user.UserRepositoryImpl, workspaceRepository workspace.WorkspaceRepository, memberRepository workspace.MemberRepository, sessionRepository session.SessionRepository, jwtService jwt.JWTService, denylist jwt.Denylist, refreshExpiry time.Duration, mailer mailer.Mailer, verification *VerificationConfig) *UserUsecaseImpl {
 	return &UserUsecaseImpl{
-		DB:             db,
-		Log:            log,
-		Validate:       validate,
-		UserRepository: userRepository,
-		JWTService:     jwtService,
+		DB:                  db,
+		Log:                 log,
+		Validate:            validate,
+		UserRepository:      userRepository,
+		WorkspaceRepository: workspaceRepository,
+		MemberRepository:    memberRepository,
+		SessionRepository:   sessionRepository,
+		JWTService:          jwtService,
+		Denylist:            denylist,
+		Mailer:              mailer,
+		RefreshExpiry:       refreshExpiry,
+		Verification:        verification,
+		helper:              helper.NewContextHelper(),
 	}
 }
 
@@ -80,11 +102,38 @@ func (u *UserUsecaseImpl) Create(ctx context.Context, request *model.RegisterReq
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
+	// Every user gets a personal workspace with their own ID, it is the default for their requests
+	personal := &entity.Workspace{
+		ID:       data.ID,
+		Name:     "Personal",
+		Personal: true,
+	}
+	if err := u.WorkspaceRepository.Create(tx, personal); err != nil {
+		u.Log.Errorf("failed to create workspace: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	member := &entity.WorkspaceMember{
+		ID:          uuid.New().String(),
+		WorkspaceID: personal.ID,
+		UserID:      data.ID,
+		Role:        model.WorkspaceRoleOwner,
+	}
+	if err := u.MemberRepository.Create(tx, member); err != nil {
+		u.Log.Errorf("failed to create workspace member: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		u.Log.Errorf("failed to commit transaction: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
+	// The user is there either way, a link that did not go out can be sent again
+	if err := u.sendVerification(ctx, data); err != nil {
+		u.Log.Errorf("failed to send verification email: %v", err)
+	}
+
 	return converter.UserToResponse(data), nil
 }
 
@@ -107,40 +156,140 @@ func (u *UserUsecaseImpl) Login(ctx context.Context, request *model.LoginRequest
 		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
 	}
 
-	accessToken, err := u.JWTService.GenerateAccessToken(data.ID, data.Email, data.Role)
-	if err != nil {
-		u.Log.Errorf("failed to generate access token: %v", err)
+	if err := u.checkVerified(data); err != nil {
+		return nil, err
+	}
+
+	// The workspace chosen at login becomes the default of the token
+	var workspaceID string
+	if request.WorkspaceID != nil {
+		if err := u.MemberRepository.GetByUser(tx, &entity.WorkspaceMember{}, *request.WorkspaceID, data.ID); err != nil {
+			u.Log.Errorf("failed to get workspace member: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusForbidden))
+		}
+		workspaceID = *request.WorkspaceID
+	}
+
+	now := time.Now()
+	sessionData := &entity.Session{
+		ID:         uuid.New().String(),
+		UserID:     data.ID,
+		TokenID:    uuid.New().String(),
+		ExpiresAt:  now.Add(u.RefreshExpiry),
+		LastUsedAt: now,
+	}
+	if request.UserAgent != "" {
+		sessionData.UserAgent = &request.UserAgent
+	}
+	if err := u.SessionRepository.Create(tx, sessionData); err != nil {
+		u.Log.Errorf("failed to create session: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	refreshToken, err := u.JWTService.GenerateRefreshToken(data.ID, data.Email, data.Role)
+	response, err := u.issueTokens(data, workspaceID, sessionData)
 	if err != nil {
-		u.Log.Errorf("failed to generate refresh token: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	return converter.LoginToTokenResponse(accessToken, refreshToken), nil
+	return response, nil
 }
 
-func (u *UserUsecaseImpl) RefreshToken(request *model.RefreshTokenRequest) (*model.TokenResponse, error) {
+// RefreshToken trades the latest refresh token of a session for a new pair, the old one stops working. A token
+// that was already traded means it leaked, so the whole session is revoked and has to log in again.
+func (u *UserUsecaseImpl) RefreshToken(ctx context.Context, request *model.RefreshTokenRequest) (*model.TokenResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
 	if err := u.Validate.Struct(request); err != nil {
 		u.Log.Errorf("failed to validate refresh token request: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusBadRequest))
 	}
 
-	claims, err := u.JWTService.ValidateToken(request.RefreshToken)
+	claims, err := u.JWTService.ValidateToken(request.RefreshToken, jwt.TokenTypeRefresh)
 	if err != nil {
 		u.Log.Errorf("failed to validate refresh token: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
 	}
 
-	accessToken, err := u.JWTService.GenerateAccessToken(claims.UserID, claims.Email, claims.Role)
+	// Tokens from before sessions carry no session
+	if claims.SessionID == "" {
+		u.Log.Errorf("refresh token without a session")
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	sessionData := &entity.Session{}
+	if err := u.SessionRepository.GetByIDForUpdate(tx, sessionData, claims.SessionID); err != nil {
+		u.Log.Errorf("failed to get session: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	now := time.Now()
+	if sessionData.UserID != claims.UserID || sessionData.RevokedAt != nil || !sessionData.ExpiresAt.After(now) {
+		u.Log.Errorf("session %s is no longer valid", sessionData.ID)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	if sessionData.TokenID != claims.ID {
+		u.Log.Warnf("refresh token reused, revoking session %s of user %s", sessionData.ID, sessionData.UserID)
+		sessionData.RevokedAt = &now
+		if err := u.SessionRepository.Update(tx, sessionData); err != nil {
+			u.Log.Errorf("failed to revoke session: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+		if err := tx.Commit().Error; err != nil {
+			u.Log.Errorf("failed to commit transaction: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	// The email and role are read again, so changes to the user reach the new tokens
+	data := &entity.User{}
+	if err := u.UserRepository.GetByID(tx, data, sessionData.UserID); err != nil {
+		u.Log.Errorf("failed to get user: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	// A session opened before verification was required does not get around it
+	if err := u.checkVerified(data); err != nil {
+		return nil, err
+	}
+
+	sessionData.TokenID = uuid.New().String()
+	sessionData.ExpiresAt = now.Add(u.RefreshExpiry)
+	sessionData.LastUsedAt = now
+	if err := u.SessionRepository.Update(tx, sessionData); err != nil {
+		u.Log.Errorf("failed to rotate session: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	response, err := u.issueTokens(data, claims.WorkspaceID, sessionData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return response, nil
+}
+
+// issueTokens signs an access token and the current refresh token of a session
+func (u *UserUsecaseImpl) issueTokens(data *entity.User, workspaceID string, sessionData *entity.Session) (*model.TokenResponse, error) {
+	accessToken, err := u.JWTService.GenerateAccessToken(data.ID, data.Email, data.Role, workspaceID, sessionData.ID)
 	if err != nil {
 		u.Log.Errorf("failed to generate access token: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
 	}
 
-	refreshToken, err := u.JWTService.GenerateRefreshToken(claims.UserID, claims.Email, claims.Role)
+	refreshToken, err := u.JWTService.GenerateRefreshToken(data.ID, data.Email, data.Role, workspaceID, sessionData.ID, sessionData.TokenID)
 	if err != nil {
 		u.Log.Errorf("failed to generate refresh token: %v", err)
 		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
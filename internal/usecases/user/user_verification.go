@@ -0,0 +1,125 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/jwt"
+	"github.com/savioruz/mikti-task/internal/platform/mailer"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type VerificationConfig struct {
+	// Required keeps users from logging in before they verified their email
+	Required bool
+	// Expiry is how long a verification link works
+	Expiry time.Duration
+	// URL is where the links point to, the token is added as the token query parameter
+	URL string
+}
+
+// Verify marks the email of a user as verified, following the same link again is not an error
+func (u *UserUsecaseImpl) Verify(ctx context.Context, request *model.VerifyEmailRequest) (*model.UserResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.JWTService.ValidateToken(request.Token, jwt.TokenTypeVerification)
+	if err != nil {
+		u.Log.Errorf("failed to validate verification token: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	data := &entity.User{}
+	if err := u.UserRepository.GetByID(tx, data, claims.UserID); err != nil {
+		u.Log.Errorf("failed to get user: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	// A link sent to an earlier address does not verify the current one
+	if data.Email != claims.Email {
+		u.Log.Errorf("verification token of user %s is for another email", data.ID)
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if data.EmailVerifiedAt == nil {
+		now := time.Now()
+		data.EmailVerifiedAt = &now
+		if err := u.UserRepository.Update(tx, data); err != nil {
+			u.Log.Errorf("failed to verify user: %v", err)
+			return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.UserToResponse(data), nil
+}
+
+// ResendVerification sends another link to a user who has not verified their email yet. Unknown and verified
+// addresses are accepted silently, so the answer does not tell which emails are registered.
+func (u *UserUsecaseImpl) ResendVerification(ctx context.Context, request *model.ResendVerificationRequest) (bool, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	data := &entity.User{}
+	if err := u.UserRepository.GetByEmail(u.DB.WithContext(ctx), data, request.Email); err != nil {
+		u.Log.Errorf("failed to get user by email: %v", err)
+		return true, nil
+	}
+
+	if data.EmailVerifiedAt != nil {
+		return true, nil
+	}
+
+	if err := u.sendVerification(ctx, data); err != nil {
+		u.Log.Errorf("failed to send verification email: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return true, nil
+}
+
+// checkVerified keeps users who did not verify their email from getting tokens while verification is required
+func (u *UserUsecaseImpl) checkVerified(data *entity.User) error {
+	if data.EmailVerifiedAt == nil && u.Verification.Required {
+		u.Log.Errorf("user %s has not verified their email", data.ID)
+		return errors.New(http.StatusText(http.StatusForbidden))
+	}
+	return nil
+}
+
+// sendVerification mails a signed link that verifies the current email of a user
+func (u *UserUsecaseImpl) sendVerification(ctx context.Context, data *entity.User) error {
+	token, err := u.JWTService.GenerateVerificationToken(data.ID, data.Email, u.Verification.Expiry)
+	if err != nil {
+		return err
+	}
+
+	link, err := url.Parse(u.Verification.URL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return u.Mailer.Send(ctx, &mailer.Message{
+		To:      data.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Open the link below to verify your email address, it works for %s.\n\n%s\n\n"+
+			"If you did not sign up, you can ignore this email.\n", u.Verification.Expiry, link),
+	})
+}
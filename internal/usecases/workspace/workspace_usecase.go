@@ -0,0 +1,20 @@
+package workspace
+
+import (
+	"context"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+)
+
+type WorkspaceUsecase interface {
+	Enter(ctx context.Context, workspaceID string) (*tenant.Workspace, error)
+	Create(ctx context.Context, request *model.WorkspaceCreateRequest) (*model.WorkspaceResponse, error)
+	GetAll(ctx context.Context) (*model.Response[[]*model.WorkspaceResponse], error)
+	Get(ctx context.Context, request *model.WorkspaceGetRequest) (*model.WorkspaceResponse, error)
+	Update(ctx context.Context, request *model.WorkspaceUpdateRequest) (*model.WorkspaceResponse, error)
+	GetMembers(ctx context.Context, request *model.WorkspaceGetRequest) (*model.Response[[]*model.WorkspaceMemberResponse], error)
+	UpdateMember(ctx context.Context, request *model.WorkspaceMemberUpdateRequest) (*model.WorkspaceMemberResponse, error)
+	RemoveMember(ctx context.Context, request *model.WorkspaceMemberDeleteRequest) (bool, error)
+	Invite(ctx context.Context, request *model.WorkspaceInviteRequest) (*model.WorkspaceInviteResponse, error)
+	AcceptInvite(ctx context.Context, request *model.WorkspaceInviteAcceptRequest) (*model.WorkspaceResponse, error)
+}
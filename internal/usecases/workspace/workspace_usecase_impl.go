@@ -0,0 +1,506 @@
+package workspace
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/savioruz/mikti-task/internal/domain/entity"
+	"github.com/savioruz/mikti-task/internal/domain/model"
+	"github.com/savioruz/mikti-task/internal/domain/model/converter"
+	"github.com/savioruz/mikti-task/internal/platform/cache"
+	"github.com/savioruz/mikti-task/internal/platform/helper"
+	"github.com/savioruz/mikti-task/internal/platform/tenant"
+	"github.com/savioruz/mikti-task/internal/repositories/share"
+	"github.com/savioruz/mikti-task/internal/repositories/user"
+	"github.com/savioruz/mikti-task/internal/repositories/workspace"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// inviteExpiry is how long an invite can be accepted
+const inviteExpiry = 7 * 24 * time.Hour
+
+type WorkspaceUsecaseImpl struct {
+	DB                  *gorm.DB
+	Cache               *cache.ImplCache
+	Log                 *logrus.Logger
+	Validate            *validator.Validate
+	WorkspaceRepository workspace.WorkspaceRepository
+	MemberRepository    workspace.MemberRepository
+	InviteRepository    workspace.InviteRepository
+	ShareRepository     share.ShareRepository
+	UserRepository      user.UserRepository
+	helper              *helper.ContextHelper
+}
+
+func NewWorkspaceUsecaseImpl(db *gorm.DB, c *cache.ImplCache, log *logrus.Logger, validate *validator.Validate, workspaceRepository workspace.WorkspaceRepository, memberRepository workspace.MemberRepository, inviteRepository workspace.InviteRepository, shareRepository share.ShareRepository, userRepository user.UserRepository) *WorkspaceUsecaseImpl {
+	return &WorkspaceUsecaseImpl{
+		DB:                  db,
+		Cache:               c,
+		Log:                 log,
+		Validate:            validate,
+		WorkspaceRepository: workspaceRepository,
+		MemberRepository:    memberRepository,
+		InviteRepository:    inviteRepository,
+		ShareRepository:     shareRepository,
+		UserRepository:      userRepository,
+		helper:              helper.NewContextHelper(),
+	}
+}
+
+// Enter resolves the workspace a request works in, the caller must be a member unless they are an admin
+func (u *WorkspaceUsecaseImpl) Enter(ctx context.Context, workspaceID string) (*tenant.Workspace, error) {
+	if err := u.Validate.Var(workspaceID, "required,uuid"); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	role, err := u.role(ctx, u.DB.WithContext(ctx), workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.Workspace{ID: workspaceID, Role: role}, nil
+}
+
+// Create starts a new workspace with the caller as its owner
+func (u *WorkspaceUsecaseImpl) Create(ctx context.Context, request *model.WorkspaceCreateRequest) (*model.WorkspaceResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	workspaceData := &entity.Workspace{
+		ID:   uuid.NewString(),
+		Name: request.Name,
+	}
+	if err := u.WorkspaceRepository.Create(tx, workspaceData); err != nil {
+		u.Log.Errorf("failed to create workspace: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	member := &entity.WorkspaceMember{
+		ID:          uuid.NewString(),
+		WorkspaceID: workspaceData.ID,
+		UserID:      claims.UserID,
+		Role:        model.WorkspaceRoleOwner,
+	}
+	if err := u.MemberRepository.Create(tx, member); err != nil {
+		u.Log.Errorf("failed to create workspace member: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.WorkspaceToResponse(workspaceData, member.Role), nil
+}
+
+// GetAll lists the workspaces the caller is a member of
+func (u *WorkspaceUsecaseImpl) GetAll(ctx context.Context) (*model.Response[[]*model.WorkspaceResponse], error) {
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	var members []entity.WorkspaceMember
+	if err := u.MemberRepository.GetByUserID(u.DB.WithContext(ctx), &members, claims.UserID); err != nil {
+		u.Log.Errorf("failed to get workspaces: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	workspaces := make([]*model.WorkspaceResponse, len(members))
+	for i := range members {
+		workspaces[i] = converter.WorkspaceToResponse(&members[i].Workspace, members[i].Role)
+	}
+
+	return model.NewResponse(workspaces, nil), nil
+}
+
+func (u *WorkspaceUsecaseImpl) Get(ctx context.Context, request *model.WorkspaceGetRequest) (*model.WorkspaceResponse, error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	db := u.DB.WithContext(ctx)
+	role, err := u.role(ctx, db, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceData := &entity.Workspace{}
+	if err := u.WorkspaceRepository.GetByID(db, workspaceData, request.ID); err != nil {
+		u.Log.Errorf("failed to get workspace: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	return converter.WorkspaceToResponse(workspaceData, role), nil
+}
+
+// Update renames a workspace, only its owner and admins may do so
+func (u *WorkspaceUsecaseImpl) Update(ctx context.Context, request *model.WorkspaceUpdateRequest) (*model.WorkspaceResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	role, err := u.manage(ctx, tx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceData := &entity.Workspace{}
+	if err := u.WorkspaceRepository.GetByID(tx, workspaceData, request.ID); err != nil {
+		u.Log.Errorf("failed to get workspace: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	workspaceData.Name = request.Name
+	if err := u.WorkspaceRepository.Update(tx, workspaceData); err != nil {
+		u.Log.Errorf("failed to update workspace: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.WorkspaceToResponse(workspaceData, role), nil
+}
+
+// GetMembers lists the members of a workspace, every member may see them
+func (u *WorkspaceUsecaseImpl) GetMembers(ctx context.Context, request *model.WorkspaceGetRequest) (*model.Response[[]*model.WorkspaceMemberResponse], error) {
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	db := u.DB.WithContext(ctx)
+	if _, err := u.role(ctx, db, request.ID); err != nil {
+		return nil, err
+	}
+
+	var members []entity.WorkspaceMember
+	if err := u.MemberRepository.GetByWorkspace(db, &members, request.ID); err != nil {
+		u.Log.Errorf("failed to get workspace members: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return model.NewResponse(converter.WorkspaceMembersToResponses(members), nil), nil
+}
+
+// UpdateMember changes the role of a member, the owner's role is fixed and only the owner changes admins
+func (u *WorkspaceUsecaseImpl) UpdateMember(ctx context.Context, request *model.WorkspaceMemberUpdateRequest) (*model.WorkspaceMemberResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	role, err := u.manage(ctx, tx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &entity.WorkspaceMember{}
+	if err := u.MemberRepository.GetByUser(tx, member, request.ID, request.UserID); err != nil {
+		u.Log.Errorf("failed to get workspace member: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if !u.outranks(role, member.Role) || (request.Role == model.WorkspaceRoleAdmin && role != model.WorkspaceRoleOwner) {
+		u.Log.Errorf("unauthorized access attempt: %s may not change a %s to %s", role, member.Role, request.Role)
+		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	member.Role = request.Role
+	if err := u.MemberRepository.Update(tx, member); err != nil {
+		u.Log.Errorf("failed to update workspace member: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	// The role decides whether the member sees the whole workspace or only what is shared
+	u.invalidateListCache(member.WorkspaceID)
+
+	return converter.WorkspaceMemberToResponse(member), nil
+}
+
+// RemoveMember takes a user out of a workspace together with the shares they hold in it. Members may leave on
+// their own, the owner can never be removed.
+func (u *WorkspaceUsecaseImpl) RemoveMember(ctx context.Context, request *model.WorkspaceMemberDeleteRequest) (bool, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return false, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	role, err := u.role(ctx, tx, request.ID)
+	if err != nil {
+		return false, err
+	}
+
+	member := &entity.WorkspaceMember{}
+	if err := u.MemberRepository.GetByUser(tx, member, request.ID, request.UserID); err != nil {
+		u.Log.Errorf("failed to get workspace member: %v", err)
+		return false, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if member.Role == model.WorkspaceRoleOwner {
+		return false, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	if member.UserID != claims.UserID && !u.outranks(role, member.Role) {
+		u.Log.Errorf("unauthorized access attempt: %s may not remove a %s", role, member.Role)
+		return false, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	if err := u.ShareRepository.PurgeInWorkspace(tx, member.UserID, member.WorkspaceID); err != nil {
+		u.Log.Errorf("failed to delete shares: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := u.MemberRepository.Purge(tx, member); err != nil {
+		u.Log.Errorf("failed to delete workspace member: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return false, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	// Their shares in the workspace went with them
+	u.invalidateListCache(member.WorkspaceID)
+
+	return true, nil
+}
+
+// Invite creates an invite for an email address, the token is returned once and only its hash is stored
+func (u *WorkspaceUsecaseImpl) Invite(ctx context.Context, request *model.WorkspaceInviteRequest) (*model.WorkspaceInviteResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	role, err := u.manage(ctx, tx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Role == model.WorkspaceRoleAdmin && role != model.WorkspaceRoleOwner {
+		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	invitee := &entity.User{}
+	if err := u.UserRepository.GetByEmail(tx, invitee, request.Email); err == nil {
+		if err := u.MemberRepository.GetByUser(tx, &entity.WorkspaceMember{}, request.ID, invitee.ID); err == nil {
+			return nil, errors.New(http.StatusText(http.StatusConflict))
+		}
+	}
+
+	token, tokenHash, err := newInviteToken()
+	if err != nil {
+		u.Log.Errorf("failed to generate invite token: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	invite := &entity.WorkspaceInvite{
+		ID:          uuid.NewString(),
+		WorkspaceID: request.ID,
+		Email:       request.Email,
+		Role:        request.Role,
+		TokenHash:   tokenHash,
+		InvitedBy:   claims.UserID,
+		ExpiresAt:   time.Now().Add(inviteExpiry),
+	}
+	if err := u.InviteRepository.Create(tx, invite); err != nil {
+		u.Log.Errorf("failed to create workspace invite: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.WorkspaceInviteToResponse(invite, token), nil
+}
+
+// AcceptInvite joins the caller to the workspace of an invite addressed to their email
+func (u *WorkspaceUsecaseImpl) AcceptInvite(ctx context.Context, request *model.WorkspaceInviteAcceptRequest) (*model.WorkspaceResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		return nil, errors.New(http.StatusText(http.StatusBadRequest))
+	}
+
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	invite := &entity.WorkspaceInvite{}
+	if err := u.InviteRepository.GetByTokenHash(tx, invite, hashInviteToken(strings.ToLower(request.Token))); err != nil {
+		u.Log.Errorf("failed to get workspace invite: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if time.Now().After(invite.ExpiresAt) {
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	if !strings.EqualFold(invite.Email, claims.Email) {
+		u.Log.Errorf("unauthorized access attempt: invite for %s accepted by %s", invite.Email, claims.Email)
+		return nil, errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	if err := u.MemberRepository.GetByUser(tx, &entity.WorkspaceMember{}, invite.WorkspaceID, claims.UserID); err == nil {
+		return nil, errors.New(http.StatusText(http.StatusConflict))
+	}
+
+	workspaceData := &entity.Workspace{}
+	if err := u.WorkspaceRepository.GetByID(tx, workspaceData, invite.WorkspaceID); err != nil {
+		u.Log.Errorf("failed to get workspace: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusNotFound))
+	}
+
+	member := &entity.WorkspaceMember{
+		ID:          uuid.NewString(),
+		WorkspaceID: invite.WorkspaceID,
+		UserID:      claims.UserID,
+		Role:        invite.Role,
+	}
+	if err := u.MemberRepository.Create(tx, member); err != nil {
+		u.Log.Errorf("failed to create workspace member: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	now := time.Now()
+	invite.AcceptedAt = &now
+	if err := u.InviteRepository.Update(tx, invite); err != nil {
+		u.Log.Errorf("failed to update workspace invite: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.Errorf("failed to commit transaction: %v", err)
+		return nil, errors.New(http.StatusText(http.StatusInternalServerError))
+	}
+
+	return converter.WorkspaceToResponse(workspaceData, member.Role), nil
+}
+
+// role returns the caller's role in a workspace, admins who are not a member act as a workspace admin
+func (u *WorkspaceUsecaseImpl) role(ctx context.Context, db *gorm.DB, workspaceID string) (string, error) {
+	claims, err := u.helper.GetJWTClaims(ctx)
+	if err != nil {
+		u.Log.Errorf("failed to get JWT claims: %v", err)
+		return "", errors.New(http.StatusText(http.StatusUnauthorized))
+	}
+
+	member := &entity.WorkspaceMember{}
+	if err := u.MemberRepository.GetByUser(db, member, workspaceID, claims.UserID); err == nil {
+		return member.Role, nil
+	}
+
+	if u.helper.IsAdmin(ctx) {
+		if err := u.WorkspaceRepository.GetByID(db, &entity.Workspace{}, workspaceID); err != nil {
+			return "", errors.New(http.StatusText(http.StatusNotFound))
+		}
+		return model.WorkspaceRoleAdmin, nil
+	}
+
+	u.Log.Errorf("unauthorized access attempt: %s is not a member of workspace %s", claims.UserID, workspaceID)
+	return "", errors.New(http.StatusText(http.StatusForbidden))
+}
+
+// manage returns the caller's role in a workspace they may manage
+func (u *WorkspaceUsecaseImpl) manage(ctx context.Context, db *gorm.DB, workspaceID string) (string, error) {
+	role, err := u.role(ctx, db, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	if !model.WorkspaceRoleManages(role) {
+		return "", errors.New(http.StatusText(http.StatusForbidden))
+	}
+
+	return role, nil
+}
+
+// outranks reports whether a member with role may change or remove a member with target,
+// the owner manages everyone else while admins only manage members and guests
+func (u *WorkspaceUsecaseImpl) outranks(role, target string) bool {
+	switch role {
+	case model.WorkspaceRoleOwner:
+		return target != model.WorkspaceRoleOwner
+	case model.WorkspaceRoleAdmin:
+		return target == model.WorkspaceRoleMember || target == model.WorkspaceRoleGuest
+	default:
+		return false
+	}
+}
+
+func newInviteToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token := hex.EncodeToString(b)
+	return token, hashInviteToken(token), nil
+}
+
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// invalidateListCache drops the todo lists cached for a workspace, a change of membership or role changes what its members may list
+func (u *WorkspaceUsecaseImpl) invalidateListCache(workspaceID string) {
+	if err := u.Cache.DeletePattern(u.helper.ListCachePattern(workspaceID)); err != nil {
+		u.Log.Errorf("failed to delete list caches: %v", err)
+	}
+}